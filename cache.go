@@ -0,0 +1,433 @@
+package rst
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+ResponseCache is an in-memory cache of the responses to GET and HEAD requests.
+It is used as a middleware:
+
+	cache := rst.NewResponseCache()
+	mux.Use(cache.Middleware)
+
+Responses are stored encoded, and compressed if they were, for the duration
+given by their Cache-Control or Expires headers, which rst derives from the TTL
+of resources. Responses marked as private, no-store or no-cache are never
+stored, and neither are the responses to requests with an Authorization header
+unless they are explicitly public.
+
+Stored responses are selected using the headers of the request listed in their
+Vary header, as well as the Range header. They are served with an Age header.
+
+Resources can tag their responses with a comma-separated list of tags in the
+Cache-Tag header, which can later be used to purge them with PurgeTag. The
+header is not sent to clients.
+
+Streamed responses, such as the ones of StreamMarshalers, Openers, collections
+and event streams, are written straight to the client, and never stored.
+*/
+type ResponseCache struct {
+	MaxEntries int // Maximum number of responses stored. No limit if 0.
+
+	mu      sync.Mutex
+	entries map[string][]*cacheEntry // Variants of the responses, by method and URL.
+	count   int
+}
+
+// cacheEntry is a response stored in a ResponseCache.
+type cacheEntry struct {
+	pattern string      // Pattern of the route that served the response.
+	tags    []string    // Values of the Cache-Tag header.
+	vary    http.Header // Values of the request headers the response varies on.
+	code    int
+	header  http.Header
+	body    []byte
+	stored  time.Time
+	expires time.Time
+}
+
+// NewResponseCache returns an empty cache.
+func NewResponseCache() *ResponseCache {
+	return &ResponseCache{entries: make(map[string][]*cacheEntry)}
+}
+
+// cacheKey returns the key of the variants of the response to r.
+func cacheKey(r *http.Request) string {
+	return r.Method + " " + r.Host + r.URL.RequestURI()
+}
+
+// Middleware serves the responses stored in c, and stores the responses that
+// can be cached. It implements the Middleware type.
+func (c *ResponseCache) Middleware(route *Route, vars RouteVars, w http.ResponseWriter, r *http.Request, next http.Handler) error {
+	if r.Method != Get && r.Method != Head || acceptsEvents(r.Header) {
+		next.ServeHTTP(w, r)
+		return nil
+	}
+
+	requestCC := ParseCacheControl(r.Header.Get("Cache-Control"))
+	if requestCC.NoStore {
+		next.ServeHTTP(w, r)
+		return nil
+	}
+
+	key := cacheKey(r)
+	if !requestCC.NoCache {
+		if entry := c.lookup(key, r); entry != nil {
+			entry.serve(w, r)
+			return nil
+		}
+	}
+
+	// Record the response, with the headers set so far. Streams are written
+	// through, bypassing the compression of w since rw compresses them.
+	rec := &responseRecorder{header: make(http.Header), code: http.StatusOK, w: w}
+	if outer, ok := w.(*responseWriter); ok {
+		rec.w = outer.ResponseWriter
+	}
+	for k, v := range w.Header() {
+		rec.header[k] = append([]string(nil), v...)
+	}
+	rw := newResponseWriter(rec)
+	next.ServeHTTP(rw, r)
+	rw.close()
+	if rec.streamed {
+		return nil
+	}
+
+	if entry := newCacheEntry(route, rec, w.Header(), r); entry != nil {
+		c.store(key, entry)
+	}
+	rec.header.Del("Cache-Tag")
+	replay(w, rec.code, rec.header, rec.body.Bytes())
+	return nil
+}
+
+// responseRecorder is an http.ResponseWriter storing the response in memory.
+// Streamed responses are written to w instead, if set.
+type responseRecorder struct {
+	header      http.Header
+	code        int
+	body        bytes.Buffer
+	wroteHeader bool
+	w           http.ResponseWriter // Writer of streamed responses. Nil to record them.
+	streamed    bool                // Set by streamResponse.
+}
+
+func (rec *responseRecorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *responseRecorder) WriteHeader(code int) {
+	if rec.wroteHeader {
+		return
+	}
+	rec.code = code
+	rec.wroteHeader = true
+	if rec.streamed && rec.w != nil {
+		for k, v := range rec.header {
+			rec.w.Header()[k] = v
+		}
+		rec.w.Header().Del("Cache-Tag")
+		rec.w.WriteHeader(code)
+	}
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	rec.WriteHeader(http.StatusOK)
+	if rec.streamed && rec.w != nil {
+		return rec.w.Write(b)
+	}
+	return rec.body.Write(b)
+}
+
+// Flush implements http.Flusher for streamed responses.
+func (rec *responseRecorder) Flush() {
+	if flusher, ok := rec.w.(http.Flusher); ok && rec.streamed {
+		flusher.Flush()
+	}
+}
+
+// streamResponse marks the response written in w as streamed, so that it is
+// written straight through by the middleware recording responses, if any.
+func streamResponse(w http.ResponseWriter) {
+	if rw, ok := w.(*responseWriter); ok {
+		w = rw.ResponseWriter
+	}
+	if rec, ok := w.(*responseRecorder); ok && !rec.wroteHeader {
+		rec.streamed = true
+	}
+}
+
+// replay writes a recorded response in w, bypassing the compression of the
+// rst response writer since body is already compressed.
+func replay(w http.ResponseWriter, code int, header http.Header, body []byte) {
+	if rw, ok := w.(*responseWriter); ok {
+		w = rw.ResponseWriter
+	}
+	for k, v := range header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(code)
+	w.Write(body)
+}
+
+// cacheableCodes are the status codes of the responses that can be stored.
+var cacheableCodes = map[int]bool{
+	http.StatusOK:                   true,
+	http.StatusNonAuthoritativeInfo: true,
+	http.StatusNoContent:            true,
+	http.StatusPartialContent:       true,
+}
+
+// newCacheEntry returns the entry storing the recorded response to r, or nil if
+// it can't be stored. Only the headers that differ from initial, the headers set
+// before the response was recorded, are stored.
+func newCacheEntry(route *Route, rec *responseRecorder, initial http.Header, r *http.Request) *cacheEntry {
+	if !cacheableCodes[rec.code] {
+		return nil
+	}
+
+	cc := ParseCacheControl(rec.header.Get("Cache-Control"))
+	if cc.Private || cc.NoStore || cc.NoCache {
+		return nil
+	}
+	if r.Header.Get("Authorization") != "" && !cc.Public && !cc.MustRevalidate && cc.SharedMaxAge == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	entry := &cacheEntry{
+		code:   rec.code,
+		body:   rec.body.Bytes(),
+		stored: now,
+		vary:   make(http.Header),
+	}
+	if route != nil {
+		entry.pattern = route.Pattern()
+	}
+
+	switch {
+	case cc.SharedMaxAge > 0:
+		entry.expires = now.Add(cc.SharedMaxAge)
+	case cc.MaxAge > 0:
+		entry.expires = now.Add(cc.MaxAge)
+	default:
+		if t, err := http.ParseTime(rec.header.Get("Expires")); err == nil {
+			entry.expires = t
+		}
+	}
+	if !entry.expires.After(now) {
+		return nil
+	}
+
+	for _, name := range append(headerList(rec.header, "Vary"), "Range") {
+		if name == "*" {
+			return nil
+		}
+		entry.vary[http.CanonicalHeaderKey(name)] = r.Header[http.CanonicalHeaderKey(name)]
+	}
+	entry.tags = headerList(rec.header, "Cache-Tag")
+	entry.header = make(http.Header)
+	for k, v := range rec.header {
+		if k != "Cache-Tag" && strings.Join(v, ",") != strings.Join(initial[k], ",") {
+			entry.header[k] = v
+		}
+	}
+	return entry
+}
+
+// headerList returns the comma-separated values of the header key.
+func headerList(header http.Header, key string) []string {
+	var list []string
+	for _, value := range header[http.CanonicalHeaderKey(key)] {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
+
+// matches returns true if e can be used to respond to r.
+func (e *cacheEntry) matches(r *http.Request) bool {
+	for name, values := range e.vary {
+		if strings.Join(r.Header[name], ",") != strings.Join(values, ",") {
+			return false
+		}
+	}
+	return true
+}
+
+// serve writes e in w, or a 304 Not Modified response if the conditional
+// headers of r match its validators.
+func (e *cacheEntry) serve(w http.ResponseWriter, r *http.Request) {
+	header := make(http.Header)
+	for k, v := range e.header {
+		header[k] = v
+	}
+	header.Set("Age", strconv.FormatInt(int64(time.Since(e.stored)/time.Second), 10))
+
+	notModified := false
+	if raw := r.Header.Get("If-None-Match"); raw != "" {
+		tags, any := parseETags(raw)
+		etag := parseETag(e.header.Get("ETag"))
+		notModified = any || (etag.opaque != "" && etag.matches(tags, true))
+	} else if d, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil {
+		if t, err := http.ParseTime(e.header.Get("Last-Modified")); err == nil {
+			notModified = !t.After(d)
+		}
+	}
+
+	if notModified {
+		header.Del("Content-Type")
+		header.Del("Content-Encoding")
+		header.Del("Content-Length")
+		replay(w, http.StatusNotModified, header, nil)
+		return
+	}
+	if r.Method == Head {
+		replay(w, e.code, header, nil)
+		return
+	}
+	replay(w, e.code, header, e.body)
+}
+
+// lookup returns a fresh entry stored for key that can be used to respond to
+// r, or nil.
+func (c *ResponseCache) lookup(key string, r *http.Request) *cacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for _, entry := range c.entries[key] {
+		if entry.expires.After(now) && entry.matches(r) {
+			return entry
+		}
+	}
+	return nil
+}
+
+// store adds entry to the variants stored for key, replacing any expired one
+// or one with the same variant.
+func (c *ResponseCache) store(key string, entry *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	var variants []*cacheEntry
+	for _, e := range c.entries[key] {
+		if e.expires.After(now) && !sameVariant(e, entry) {
+			variants = append(variants, e)
+		} else {
+			c.count--
+		}
+	}
+	c.entries[key] = append(variants, entry)
+	c.count++
+
+	if c.MaxEntries > 0 {
+		for c.count > c.MaxEntries {
+			c.evict()
+		}
+	}
+}
+
+// sameVariant returns true if a and b are selected by the same requests.
+func sameVariant(a, b *cacheEntry) bool {
+	if len(a.vary) != len(b.vary) {
+		return false
+	}
+	for name, values := range a.vary {
+		if strings.Join(values, ",") != strings.Join(b.vary[name], ",") {
+			return false
+		}
+	}
+	return true
+}
+
+// evict removes the entry that expires first. c.mu must be held.
+func (c *ResponseCache) evict() {
+	var (
+		oldestKey string
+		oldest    *cacheEntry
+	)
+	for key, variants := range c.entries {
+		for _, e := range variants {
+			if oldest == nil || e.expires.Before(oldest.expires) {
+				oldestKey, oldest = key, e
+			}
+		}
+	}
+	if oldest != nil {
+		c.remove(oldestKey, func(e *cacheEntry) bool { return e == oldest })
+	}
+}
+
+// remove deletes the entries stored for key matching fn, and returns their
+// number. c.mu must be held.
+func (c *ResponseCache) remove(key string, fn func(e *cacheEntry) bool) int {
+	var kept []*cacheEntry
+	removed := 0
+	for _, e := range c.entries[key] {
+		if fn(e) {
+			removed++
+		} else {
+			kept = append(kept, e)
+		}
+	}
+	if len(kept) == 0 {
+		delete(c.entries, key)
+	} else {
+		c.entries[key] = kept
+	}
+	c.count -= removed
+	return removed
+}
+
+// purge removes all the entries matching fn, and returns their number.
+func (c *ResponseCache) purge(fn func(e *cacheEntry) bool) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	removed := 0
+	for key := range c.entries {
+		removed += c.remove(key, fn)
+	}
+	return removed
+}
+
+// PurgePattern removes the responses served by the routes registered with
+// pattern, including the prefix of their group, and returns their number.
+//
+//	cache.PurgePattern("/people/{id}")
+func (c *ResponseCache) PurgePattern(pattern string) int {
+	return c.purge(func(e *cacheEntry) bool {
+		return e.pattern == pattern
+	})
+}
+
+// PurgeTag removes the responses tagged with tag in their Cache-Tag header,
+// and returns their number.
+func (c *ResponseCache) PurgeTag(tag string) int {
+	return c.purge(func(e *cacheEntry) bool {
+		for _, t := range e.tags {
+			if t == tag {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Len returns the number of responses stored in c, including expired ones that
+// haven't been removed yet.
+func (c *ResponseCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.count
+}
@@ -0,0 +1,207 @@
+package rst
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type cachedPerson struct {
+	ID string
+}
+
+func newCachedMux(cache *ResponseCache) (*Mux, *int) {
+	calls := new(int)
+	mux := NewMux()
+	mux.Debug = true
+	mux.Use(cache.Middleware)
+	mux.Get("/people/{id}", func(vars RouteVars, r *http.Request) (Resource, error) {
+		*calls++
+		envelope := NewEnvelope(&cachedPerson{ID: vars.Get("id")}, testTimeReference, "", time.Minute)
+		envelope.Header().Set("Cache-Tag", "people, person-"+vars.Get("id"))
+		return envelope, nil
+	})
+	mux.Get("/private", func(vars RouteVars, r *http.Request) (Resource, error) {
+		*calls++
+		envelope := NewEnvelope("private", testTimeReference, "", time.Minute)
+		envelope.SetCacheControl(&CacheControl{Private: true, MaxAge: time.Minute})
+		return envelope, nil
+	})
+	return mux, calls
+}
+
+func cacheRequest(mux *Mux, path string, header http.Header) *httptest.ResponseRecorder {
+	r, _ := http.NewRequest(Get, "http://www.example.com"+path, nil)
+	for k, v := range header {
+		r.Header[k] = v
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	return w
+}
+
+func TestResponseCache(t *testing.T) {
+	cache := NewResponseCache()
+	mux, calls := newCachedMux(cache)
+
+	first := cacheRequest(mux, "/people/1", nil)
+	if first.Code != http.StatusOK || *calls != 1 {
+		t.Fatal("Got:", first.Code, *calls, "calls")
+	}
+	if first.Header().Get("Age") != "" || first.Header().Get("Cache-Tag") != "" {
+		t.Fatal("unexpected headers in a fresh response:", first.Header())
+	}
+
+	second := cacheRequest(mux, "/people/1", nil)
+	if *calls != 1 {
+		t.Fatal("response was not served from the cache")
+	}
+	if second.Header().Get("Age") != "0" || second.Header().Get("Cache-Tag") != "" {
+		t.Fatal("unexpected headers in a cached response:", second.Header())
+	}
+	if second.Body.String() != first.Body.String() || second.Header().Get("Content-Type") != first.Header().Get("Content-Type") {
+		t.Fatal("cached response differs from the original")
+	}
+
+	if w := cacheRequest(mux, "/people/1", http.Header{"Cache-Control": {"no-cache"}}); w.Code != http.StatusOK || *calls != 2 {
+		t.Fatal("no-cache request was served from the cache")
+	}
+	if w := cacheRequest(mux, "/private", nil); w.Code != http.StatusOK || cache.Len() != 1 {
+		t.Fatal("private response was stored")
+	}
+}
+
+type cachedStream struct {
+	write func(io.Writer) error
+}
+
+func (s *cachedStream) ETag() string            { return "stream" }
+func (s *cachedStream) LastModified() time.Time { return testTimeReference }
+func (s *cachedStream) TTL() time.Duration      { return time.Minute }
+
+func (s *cachedStream) StreamContentType(r *http.Request) (string, error) {
+	return "text/plain; charset=utf-8", nil
+}
+
+func (s *cachedStream) MarshalStream(w io.Writer, r *http.Request) error {
+	return s.write(w)
+}
+
+func TestResponseCacheStream(t *testing.T) {
+	cache := NewResponseCache()
+	mux := NewMux()
+	mux.Use(cache.Middleware)
+	var w *httptest.ResponseRecorder
+	mux.Get("/export", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return &cachedStream{func(sw io.Writer) error {
+			io.WriteString(sw, "line 1\n")
+			sw.(http.Flusher).Flush()
+			if !w.Flushed || w.Body.String() != "line 1\n" {
+				t.Error("stream was buffered by the cache")
+			}
+			_, err := io.WriteString(sw, "line 2\n")
+			return err
+		}}, nil
+	})
+
+	for i := 0; i < 2; i++ {
+		r, _ := http.NewRequest(Get, "http://www.example.com/export", nil)
+		w = httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		if w.Code != http.StatusOK || w.Body.String() != "line 1\nline 2\n" || w.Header().Get("Age") != "" {
+			t.Fatal("Got:", w.Code, w.Header(), w.Body.String())
+		}
+	}
+	if cache.Len() != 0 {
+		t.Fatal("Got:", cache.Len(), "entries", "Wanted:", 0)
+	}
+}
+
+func TestResponseCacheVary(t *testing.T) {
+	cache := NewResponseCache()
+	mux, calls := newCachedMux(cache)
+
+	jsonHeader := http.Header{"Accept": {"application/json"}}
+	xmlHeader := http.Header{"Accept": {"application/xml"}}
+	cacheRequest(mux, "/people/1", jsonHeader)
+	cacheRequest(mux, "/people/1", xmlHeader)
+	if *calls != 2 || cache.Len() != 2 {
+		t.Fatal("Got:", *calls, "calls and", cache.Len(), "entries. Wanted: 2")
+	}
+
+	w := cacheRequest(mux, "/people/1", xmlHeader)
+	if *calls != 2 || w.Header().Get("Content-Type") != "application/xml; charset=utf-8" {
+		t.Fatal("wrong variant served:", w.Header().Get("Content-Type"))
+	}
+	w = cacheRequest(mux, "/people/1", jsonHeader)
+	if *calls != 2 || w.Header().Get("Content-Type") != "application/json; charset=utf-8" {
+		t.Fatal("wrong variant served:", w.Header().Get("Content-Type"))
+	}
+}
+
+func TestResponseCacheExpiry(t *testing.T) {
+	cache := NewResponseCache()
+	mux, calls := newCachedMux(cache)
+
+	cacheRequest(mux, "/people/1", nil)
+	for _, variants := range cache.entries {
+		for _, e := range variants {
+			e.expires = time.Now().Add(-time.Second)
+		}
+	}
+	cacheRequest(mux, "/people/1", nil)
+	if *calls != 2 || cache.Len() != 1 {
+		t.Fatal("expired response was served or kept:", *calls, cache.Len())
+	}
+}
+
+func TestResponseCacheNotModified(t *testing.T) {
+	cache := NewResponseCache()
+	mux, _ := newCachedMux(cache)
+
+	cacheRequest(mux, "/people/1", nil)
+	w := cacheRequest(mux, "/people/1", http.Header{
+		"If-Modified-Since": {testTimeReference.UTC().Format(rfc1123)},
+	})
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 || w.Header().Get("Age") == "" {
+		t.Fatal("Got:", w.Code, "Wanted:", http.StatusNotModified)
+	}
+}
+
+func TestResponseCachePurge(t *testing.T) {
+	cache := NewResponseCache()
+	mux, calls := newCachedMux(cache)
+
+	for _, path := range []string{"/people/1", "/people/2", "/people/3"} {
+		cacheRequest(mux, path, nil)
+	}
+	if n := cache.PurgeTag("person-2"); n != 1 || cache.Len() != 2 {
+		t.Fatal("Got:", n, "purged. Wanted: 1")
+	}
+	cacheRequest(mux, "/people/2", nil)
+	if *calls != 4 {
+		t.Fatal("purged response was served from the cache")
+	}
+	if n := cache.PurgePattern("/people/{id}"); n != 3 || cache.Len() != 0 {
+		t.Fatal("Got:", n, "purged. Wanted: 3")
+	}
+}
+
+func TestResponseCacheMaxEntries(t *testing.T) {
+	cache := NewResponseCache()
+	cache.MaxEntries = 2
+	mux, calls := newCachedMux(cache)
+
+	for _, path := range []string{"/people/1", "/people/2", "/people/3"} {
+		cacheRequest(mux, path, nil)
+	}
+	if cache.Len() != 2 {
+		t.Fatal("Got:", cache.Len(), "entries. Wanted: 2")
+	}
+	cacheRequest(mux, "/people/3", nil)
+	if *calls != 3 {
+		t.Fatal("latest response was evicted")
+	}
+}
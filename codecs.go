@@ -0,0 +1,232 @@
+package rst
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+/*
+Codec encodes and decodes resources in the formats identified by a set of media
+MIME types.
+
+Either Marshal or Unmarshal can be nil if the codec only supports one
+direction.
+
+	var msgpackCodec = &rst.Codec{
+		ContentType: "application/msgpack",
+		MediaTypes:  []string{"application/msgpack", "application/x-msgpack"},
+		Marshal:     msgpack.Marshal,
+		Unmarshal:   msgpack.Unmarshal,
+	}
+
+	rst.DefaultCodecs.Register(msgpackCodec)
+*/
+type Codec struct {
+	// Value of the Content-Type header of responses encoded with this codec.
+	// The first item of MediaTypes will be used if empty.
+	ContentType string
+
+	// Media types supported by this codec, by order of preference.
+	MediaTypes []string
+
+	// Marshal returns the encoding of v.
+	Marshal func(v interface{}) ([]byte, error)
+
+	// Unmarshal parses the encoded data and stores the result in the value
+	// pointed to by v.
+	Unmarshal func(data []byte, v interface{}) error
+}
+
+// contentType returns the value of the Content-Type header of responses encoded
+// with c.
+func (c *Codec) contentType() string {
+	if c.ContentType != "" {
+		return c.ContentType
+	}
+	return c.MediaTypes[0]
+}
+
+// supports returns true if mediaType is one of the media types of c.
+func (c *Codec) supports(mediaType string) bool {
+	for _, mt := range c.MediaTypes {
+		if strings.EqualFold(mt, mediaType) {
+			return true
+		}
+	}
+	return false
+}
+
+// JSONCodec encodes and decodes resources using encoding/json.
+var JSONCodec = &Codec{
+	ContentType: "application/json; charset=utf-8",
+	MediaTypes:  []string{"application/json", "text/javascript"},
+	Marshal: func(v interface{}) ([]byte, error) {
+		b, err := json.Marshal(v)
+		if bytes.Equal(b, jsonNull) {
+			b = []byte{}
+		}
+		return b, err
+	},
+	Unmarshal: json.Unmarshal,
+}
+
+// XMLCodec encodes and decodes resources using encoding/xml.
+//
+// Its encoding will always return a valid XML document with a header and a root
+// object, which is not the case for the encoding/xml package.
+var XMLCodec = &Codec{
+	ContentType: "application/xml; charset=utf-8",
+	MediaTypes:  []string{"application/xml", "text/xml"},
+	Marshal:     marshalXML,
+	Unmarshal:   xml.Unmarshal,
+}
+
+// TextCodec encodes resources that implement either encoding.TextMarshaler or
+// fmt.Stringer. Other resources are not acceptable.
+var TextCodec = &Codec{
+	ContentType: "text/plain; charset=utf-8",
+	MediaTypes:  []string{"text/plain"},
+	Marshal: func(v interface{}) ([]byte, error) {
+		if marshaler, implemented := v.(encoding.TextMarshaler); implemented {
+			return marshaler.MarshalText()
+		}
+		if marshaler, implemented := v.(fmt.Stringer); implemented {
+			return []byte(marshaler.String()), nil
+		}
+		return nil, NotAcceptable()
+	},
+}
+
+// FormCodec decodes form-urlencoded entities into a url.Values, a map, or a
+// struct with fields optionally tagged with "form".
+var FormCodec = &Codec{
+	MediaTypes: []string{"application/x-www-form-urlencoded"},
+	Unmarshal: func(data []byte, v interface{}) error {
+		values, err := url.ParseQuery(string(data))
+		if err != nil {
+			return err
+		}
+		return unmarshalForm(values, v)
+	},
+}
+
+// DefaultCodecs is the registry used by a Mux when none is specified, and by
+// Marshal and Unmarshal outside of a Mux.
+var DefaultCodecs = NewCodecs(JSONCodec, XMLCodec, TextCodec, FormCodec)
+
+// Codecs is a registry of codecs, used to negotiate the format in which
+// resources are encoded, and to decode the entities of requests.
+//
+// It is safe for concurrent use.
+type Codecs struct {
+	mu   sync.RWMutex
+	list []*Codec
+}
+
+// NewCodecs returns a registry with the given codecs, by order of preference.
+func NewCodecs(codecs ...*Codec) *Codecs {
+	c := &Codecs{}
+	for _, codec := range codecs {
+		c.Register(codec)
+	}
+	return c
+}
+
+// Register adds codec to the registry. It replaces any codec previously
+// registered for one of the same media types, and takes the lowest preference
+// otherwise.
+func (c *Codecs) Register(codec *Codec) {
+	if len(codec.MediaTypes) == 0 {
+		panic(fmt.Errorf("codec %q must declare at least one media type", codec.ContentType))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, registered := range c.list {
+		for _, mt := range codec.MediaTypes {
+			if registered.supports(mt) {
+				c.list[i] = codec
+				return
+			}
+		}
+	}
+	c.list = append(c.list, codec)
+}
+
+// Encodable returns the media types that can be encoded with the codecs of the
+// registry, by order of preference.
+func (c *Codecs) Encodable() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var mediaTypes []string
+	for _, codec := range c.list {
+		if codec.Marshal != nil {
+			mediaTypes = append(mediaTypes, codec.MediaTypes...)
+		}
+	}
+	return mediaTypes
+}
+
+// Decodable returns the media types that can be decoded with the codecs of the
+// registry, by order of preference.
+func (c *Codecs) Decodable() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var mediaTypes []string
+	for _, codec := range c.list {
+		if codec.Unmarshal != nil {
+			mediaTypes = append(mediaTypes, codec.MediaTypes...)
+		}
+	}
+	return mediaTypes
+}
+
+// Negotiate returns the codec that should be used to encode a resource given
+// the clauses of an Accept header, or nil if none is acceptable.
+func (c *Codecs) Negotiate(accept Accept) *Codec {
+	return c.encoder(accept.Negotiate(c.Encodable()...))
+}
+
+// encoder returns the codec that can encode mediaType, or nil.
+func (c *Codecs) encoder(mediaType string) *Codec {
+	if mediaType == "" {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, codec := range c.list {
+		if codec.Marshal != nil && codec.supports(mediaType) {
+			return codec
+		}
+	}
+	return nil
+}
+
+// decoder returns the codec that can decode mediaType, or nil.
+func (c *Codecs) decoder(mediaType string) *Codec {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, codec := range c.list {
+		if codec.Unmarshal != nil && codec.supports(mediaType) {
+			return codec
+		}
+	}
+	return nil
+}
+
+// getCodecs returns the registry of the Mux serving r, or DefaultCodecs.
+func getCodecs(r *http.Request) *Codecs {
+	if r != nil {
+		if c, ok := r.Context().Value(codecsKey).(*Codecs); ok && c != nil {
+			return c
+		}
+	}
+	return DefaultCodecs
+}
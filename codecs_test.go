@@ -0,0 +1,94 @@
+package rst
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var testCSVCodec = &Codec{
+	ContentType: "text/csv; charset=utf-8",
+	MediaTypes:  []string{"text/csv"},
+	Marshal: func(v interface{}) ([]byte, error) {
+		p, ok := v.(*person)
+		if !ok {
+			return nil, errors.New("unsupported type")
+		}
+		return []byte(strings.Join([]string{p.ID, p.Firstname, p.Lastname}, ",")), nil
+	},
+}
+
+func TestCodecsRegister(t *testing.T) {
+	codecs := NewCodecs(JSONCodec, XMLCodec)
+	codecs.Register(testCSVCodec)
+
+	expected := []string{"application/json", "text/javascript", "application/xml", "text/xml", "text/csv"}
+	if got := codecs.Encodable(); strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Fatal("Got:", got, "Wanted:", expected)
+	}
+
+	// Replacing the JSON codec keeps its preference.
+	custom := &Codec{MediaTypes: []string{"application/json"}, Marshal: JSONCodec.Marshal}
+	codecs.Register(custom)
+	if c := codecs.Negotiate(ParseAccept("*/*")); c != custom {
+		t.Fatal("expected custom JSON codec to be negotiated")
+	}
+	if c := codecs.Negotiate(ParseAccept("text/javascript")); c != nil {
+		t.Fatal("text/javascript should no longer be supported")
+	}
+
+	if d := codecs.Decodable(); len(d) != 2 || d[0] != "application/xml" {
+		t.Fatal("Got:", d, "Wanted: [application/xml text/xml]")
+	}
+}
+
+func TestCodecsNegotiate(t *testing.T) {
+	var test = func(accept string, expected *Codec) {
+		if c := DefaultCodecs.Negotiate(ParseAccept(accept)); c != expected {
+			t.Errorf("%s: unexpected codec %v", accept, c)
+		}
+	}
+	test("application/json", JSONCodec)
+	test("text/xml", XMLCodec)
+	test("text/plain", TextCodec)
+	test("*/*", JSONCodec)
+	test("application/x-www-form-urlencoded", nil)
+	test("image/png", nil)
+}
+
+func TestMuxCodecs(t *testing.T) {
+	mux := NewMux()
+	mux.Codecs = NewCodecs(JSONCodec, testCSVCodec)
+	mux.Handle("/people", EndpointHandler(&peopleCollection{}))
+	mux.Handle("/people/{id}", EndpointHandler(&personResource{}))
+
+	var serve = func(method, path, accept string) *httptest.ResponseRecorder {
+		r, _ := http.NewRequest(method, "http://www.example.com"+path, nil)
+		if accept != "" {
+			r.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w
+	}
+
+	w := serve(Get, "/people/"+testPeople[1].ID, "text/csv")
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != testCSVCodec.ContentType {
+		t.Fatal("Got:", w.Code, w.Header(), "Wanted:", http.StatusOK, testCSVCodec.ContentType)
+	}
+	expected := strings.Join([]string{testPeople[1].ID, testPeople[1].Firstname, testPeople[1].Lastname}, ",")
+	if w.Body.String() != expected {
+		t.Fatal("Got:", w.Body.String(), "Wanted:", expected)
+	}
+
+	// XML is not registered in this mux.
+	if w := serve(Get, "/people/"+testPeople[1].ID, "application/xml"); w.Code != http.StatusNotAcceptable {
+		t.Fatal("Got:", w.Code, "Wanted:", http.StatusNotAcceptable)
+	}
+
+	if w := serve(Options, "/people", ""); !strings.Contains(w.Header().Get("Content-Type"), "text/csv") {
+		t.Fatal("Got:", w.Header().Get("Content-Type"), "Wanted: text/csv")
+	}
+}
@@ -0,0 +1,374 @@
+package rst
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Media types of newline-delimited JSON, in which each line is the JSON
+// encoding of an item of a collection.
+const (
+	ndjsonMediaType = "application/x-ndjson"
+	jsonlMediaType  = "application/jsonl"
+)
+
+// DefaultCollectionFlushInterval is the minimal interval at which the items of
+// a Collection written in a response are flushed to the client, unless the
+// CollectionFlushInterval field of the mux is set.
+const DefaultCollectionFlushInterval = 100 * time.Millisecond
+
+// collectionFormat is the syntax in which the items of a Collection are
+// written.
+type collectionFormat int
+
+const (
+	collectionJSON   collectionFormat = iota // JSON array.
+	collectionNDJSON                         // One JSON item per line.
+	collectionXML                            // XML list.
+)
+
+// Iterator returns the items of a collection one at a time. Iterators
+// implementing io.Closer are closed once the collection has been written.
+type Iterator interface {
+	// Next returns the next item of the collection, or io.EOF when there
+	// are no more items.
+	Next() (interface{}, error)
+}
+
+// IteratorFunc allows a function to be used as an Iterator.
+type IteratorFunc func() (interface{}, error)
+
+// Next implements the Iterator interface.
+func (f IteratorFunc) Next() (interface{}, error) {
+	return f()
+}
+
+// chanIterator returns the values received from a channel.
+type chanIterator reflect.Value
+
+func (ch chanIterator) Next() (interface{}, error) {
+	v, ok := reflect.Value(ch).Recv()
+	if !ok {
+		return nil, io.EOF
+	}
+	return v.Interface(), nil
+}
+
+// ChanIterator returns an Iterator returning the values received from ch, a
+// channel of any type, until it's closed.
+func ChanIterator(ch interface{}) Iterator {
+	v := reflect.ValueOf(ch)
+	if v.Kind() != reflect.Chan || v.Type().ChanDir()&reflect.RecvDir == 0 {
+		panic("rst: ChanIterator requires a channel that can receive")
+	}
+	return chanIterator(v)
+}
+
+/*
+Collection is a resource streaming the items returned by an Iterator, without
+holding them all in memory.
+
+Clients accepting application/x-ndjson or application/jsonl receive one JSON
+encoded item per line. Otherwise, the items are encoded with the negotiated
+codec of the mux, among those producing JSON or XML, and written in a JSON
+array, or in an XML list named after the type of the items, like slices encoded
+by XMLCodec.
+
+	func (ep *PeopleEP) Get(vars rst.RouteVars, r *http.Request) (rst.Resource, error) {
+		people := database.People() // chan *Person
+		return rst.NewCollection(rst.ChanIterator(people), time.Now(), "", 0), nil
+	}
+
+A collection can only be written once.
+*/
+type Collection struct {
+	iter         Iterator
+	lastModified time.Time
+	etag         string
+	ttl          time.Duration
+}
+
+// NewCollection returns a new Collection streaming the items returned by
+// iter.
+func NewCollection(iter Iterator, lastModified time.Time, etag string, ttl time.Duration) *Collection {
+	return &Collection{
+		iter:         iter,
+		lastModified: lastModified,
+		etag:         etag,
+		ttl:          ttl,
+	}
+}
+
+// TTL implements the rst.Resource interface.
+func (c *Collection) TTL() time.Duration {
+	return c.ttl
+}
+
+// LastModified implements the rst.Resource interface.
+func (c *Collection) LastModified() time.Time {
+	return c.lastModified
+}
+
+// ETag implements the rst.Resource interface.
+func (c *Collection) ETag() string {
+	return c.etag
+}
+
+// codecFormat returns the syntax in which the items of a collection are
+// written with codec, or false if the codec produces neither JSON nor XML.
+func codecFormat(codec *Codec) (collectionFormat, bool) {
+	for _, mt := range codec.MediaTypes {
+		subtype := strings.ToLower(mt[strings.Index(mt, "/")+1:])
+		switch {
+		case subtype == "json" || strings.HasSuffix(subtype, "+json"):
+			return collectionJSON, true
+		case subtype == "xml" || strings.HasSuffix(subtype, "+xml"):
+			return collectionXML, true
+		}
+	}
+	return 0, false
+}
+
+// negotiate returns the codec encoding the items of c in response to r, and
+// the syntax in which they're written, or a nil codec if no format is
+// acceptable.
+func (c *Collection) negotiate(r *http.Request) (*Codec, collectionFormat) {
+	codecs := getCodecs(r)
+	var alternatives []string
+	for _, mt := range codecs.Encodable() {
+		if _, ok := codecFormat(codecs.encoder(mt)); ok {
+			alternatives = append(alternatives, mt)
+		}
+	}
+	alternatives = append(alternatives, ndjsonMediaType, jsonlMediaType)
+
+	accept := ParseAccept(r.Header.Get("Accept"))
+	mediaType := alternatives[0]
+	if len(accept) > 0 {
+		mediaType = accept.Negotiate(alternatives...)
+	}
+	switch mediaType {
+	case "":
+		return nil, 0
+	case ndjsonMediaType, jsonlMediaType:
+		codec := codecs.encoder("application/json")
+		if codec == nil {
+			codec = JSONCodec
+		}
+		return &Codec{ContentType: mediaType, MediaTypes: []string{mediaType}, Marshal: codec.Marshal}, collectionNDJSON
+	}
+	codec := codecs.encoder(mediaType)
+	format, _ := codecFormat(codec)
+	return codec, format
+}
+
+// StreamContentType implements the StreamMarshaler interface.
+func (c *Collection) StreamContentType(r *http.Request) (string, error) {
+	codec, _ := c.negotiate(r)
+	if codec == nil {
+		return "", NotAcceptable()
+	}
+	return codec.contentType(), nil
+}
+
+// MarshalStream implements the StreamMarshaler interface.
+func (c *Collection) MarshalStream(w io.Writer, r *http.Request) error {
+	if closer, ok := c.iter.(io.Closer); ok {
+		defer closer.Close()
+	}
+	codec, format := c.negotiate(r)
+	if codec == nil {
+		return NotAcceptable()
+	}
+
+	// The first item is read before anything is written, so that the errors
+	// of the iterator can still be returned to the client.
+	item, err := c.iter.Next()
+	if err != nil && err != io.EOF {
+		return err
+	}
+	empty := err == io.EOF
+
+	cw := &collectionWriter{w: w, last: time.Now(), interval: collectionFlushInterval(r)}
+	switch format {
+	case collectionNDJSON:
+		for !empty {
+			b, err := marshalCollectionItem(codec, format, item)
+			if err != nil {
+				return err
+			}
+			cw.write(b, []byte("\n"))
+			if item, err = cw.next(c.iter); err != nil {
+				break
+			}
+		}
+
+	case collectionXML:
+		name := "List"
+		if t := reflect.TypeOf(item); !empty && t != nil {
+			fqn := strings.Split(t.String(), ".")
+			name = fqn[len(fqn)-1] + "List"
+		}
+		cw.write([]byte(xml.Header), []byte("<"+name+">"))
+		for !empty {
+			b, err := marshalCollectionItem(codec, format, item)
+			if err != nil {
+				return err
+			}
+			cw.write(b)
+			if item, err = cw.next(c.iter); err != nil {
+				break
+			}
+		}
+		cw.write([]byte("</" + name + ">"))
+
+	default:
+		cw.write([]byte("["))
+		for i := 0; !empty; i++ {
+			b, err := marshalCollectionItem(codec, format, item)
+			if err != nil {
+				return err
+			}
+			if i > 0 {
+				cw.write([]byte(","))
+			}
+			cw.write(b)
+			if item, err = cw.next(c.iter); err != nil {
+				break
+			}
+		}
+		cw.write([]byte("]"))
+	}
+
+	return cw.err
+}
+
+// marshalCollectionItem returns the encoding of item with codec, without the
+// XML declaration codecs add to documents. Nil items are encoded as JSON null,
+// or as nothing in XML.
+func marshalCollectionItem(codec *Codec, format collectionFormat, item interface{}) ([]byte, error) {
+	if item == nil {
+		if format == collectionXML {
+			return nil, nil
+		}
+		return jsonNull, nil
+	}
+	b, err := codec.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case format == collectionXML && bytes.HasPrefix(b, []byte("<?xml")):
+		if end := bytes.Index(b, []byte("?>")); end >= 0 {
+			b = bytes.TrimLeft(b[end+2:], " \t\r\n")
+		}
+	case format != collectionXML && len(b) == 0:
+		b = jsonNull
+	}
+	return b, nil
+}
+
+// collectionFlushInterval returns the minimal interval at which the items of
+// the collections written in response to r are flushed.
+func collectionFlushInterval(r *http.Request) time.Duration {
+	if route, _ := r.Context().Value(routeKey).(*Route); route != nil {
+		return route.mux.collectionFlushInterval()
+	}
+	return DefaultCollectionFlushInterval
+}
+
+// collectionWriter writes the items of a collection, and flushes them at most
+// every interval.
+type collectionWriter struct {
+	w        io.Writer
+	last     time.Time
+	interval time.Duration
+	pending  bool // Set when something was written since the last flush.
+	err      error
+}
+
+// write writes chunks in w, unless an error occurred already.
+func (cw *collectionWriter) write(chunks ...[]byte) {
+	for _, b := range chunks {
+		if cw.err != nil {
+			return
+		}
+		_, cw.err = cw.w.Write(b)
+		cw.pending = true
+	}
+}
+
+// flush sends the items written so far to the client.
+func (cw *collectionWriter) flush() {
+	cw.w.(http.Flusher).Flush()
+	cw.last, cw.pending = time.Now(), false
+}
+
+// next returns the next item of iter. It returns io.EOF at the end of the
+// collection, or the error that occurred.
+//
+// The items written so far are flushed once the interval has elapsed since
+// the last flush, including while iter blocks.
+func (cw *collectionWriter) next(iter Iterator) (interface{}, error) {
+	if cw.err != nil {
+		return nil, cw.err
+	}
+	var (
+		item interface{}
+		err  error
+	)
+	_, flushes := cw.w.(http.Flusher)
+	wait := cw.interval - time.Since(cw.last)
+	switch {
+	case !flushes || !cw.pending:
+		item, err = iter.Next()
+	case wait <= 0:
+		cw.flush()
+		item, err = iter.Next()
+	default:
+		item, err = cw.nextWithin(iter, wait)
+	}
+	if err != nil && err != io.EOF {
+		cw.err = err
+	}
+	return item, err
+}
+
+// nextWithin returns the next item of iter, and flushes the items written so
+// far if iter blocks for longer than wait. Panics raised by iter are raised
+// again in the calling goroutine.
+func (cw *collectionWriter) nextWithin(iter Iterator, wait time.Duration) (interface{}, error) {
+	type result struct {
+		item     interface{}
+		err      error
+		panicked interface{}
+	}
+	results := make(chan *result, 1)
+	go func() {
+		res := new(result)
+		defer func() {
+			res.panicked = recover()
+			results <- res
+		}()
+		res.item, res.err = iter.Next()
+	}()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	var res *result
+	select {
+	case res = <-results:
+	case <-timer.C:
+		cw.flush()
+		res = <-results
+	}
+	if res.panicked != nil {
+		panic(res.panicked)
+	}
+	return res.item, res.err
+}
@@ -0,0 +1,221 @@
+package rst
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type collectionItem struct {
+	ID int `json:"id"`
+}
+
+type sliceIterator struct {
+	items  []*collectionItem
+	err    error
+	closed bool
+}
+
+func (it *sliceIterator) Next() (interface{}, error) {
+	if it.err != nil {
+		return nil, it.err
+	}
+	if len(it.items) == 0 {
+		return nil, io.EOF
+	}
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+func (it *sliceIterator) Close() error {
+	it.closed = true
+	return nil
+}
+
+func serveCollection(iter Iterator, accept string) *httptest.ResponseRecorder {
+	mux := NewMux()
+	mux.Debug = true
+	mux.Get("/items", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return NewCollection(iter, testTimeReference, "items", 0), nil
+	})
+	r, _ := http.NewRequest(Get, "http://www.example.com/items", nil)
+	r.Header.Set("Accept", accept)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	return w
+}
+
+func newSliceIterator() *sliceIterator {
+	return &sliceIterator{items: []*collectionItem{{1}, {2}, {3}}}
+}
+
+func TestCollectionFormats(t *testing.T) {
+	var test = func(accept, contentType, body string) {
+		iter := newSliceIterator()
+		w := serveCollection(iter, accept)
+		if w.Code != http.StatusOK {
+			t.Fatal(accept, "Got:", w.Code, "Wanted:", http.StatusOK)
+		}
+		if got := w.Header().Get("Content-Type"); got != contentType {
+			t.Fatal(accept, "Got:", got, "Wanted:", contentType)
+		}
+		if w.Body.String() != body {
+			t.Fatalf("%s Got: %q Wanted: %q", accept, w.Body.String(), body)
+		}
+		if !iter.closed {
+			t.Fatal(accept, "iterator was not closed")
+		}
+	}
+	test("application/x-ndjson", "application/x-ndjson", "{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n")
+	test("application/jsonl", "application/jsonl", "{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n")
+	test("", "application/json; charset=utf-8", `[{"id":1},{"id":2},{"id":3}]`)
+	test("application/json", "application/json; charset=utf-8", `[{"id":1},{"id":2},{"id":3}]`)
+	test("application/xml", "application/xml; charset=utf-8", `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+		`<collectionItemList><collectionItem><ID>1</ID></collectionItem><collectionItem><ID>2</ID></collectionItem><collectionItem><ID>3</ID></collectionItem></collectionItemList>`)
+}
+
+func TestCollectionEmpty(t *testing.T) {
+	w := serveCollection(&sliceIterator{}, "application/json")
+	if w.Code != http.StatusOK || w.Body.String() != "[]" {
+		t.Fatal("Got:", w.Code, w.Body.String(), "Wanted: []")
+	}
+	w = serveCollection(&sliceIterator{}, "application/x-ndjson")
+	if w.Code != http.StatusOK || w.Body.Len() != 0 {
+		t.Fatal("Got:", w.Code, w.Body.String(), "Wanted: empty body")
+	}
+}
+
+func TestCollectionErrors(t *testing.T) {
+	if w := serveCollection(&sliceIterator{err: errors.New("unavailable")}, "application/json"); w.Code != http.StatusInternalServerError {
+		t.Fatal("Got:", w.Code, "Wanted:", http.StatusInternalServerError)
+	}
+	if w := serveCollection(newSliceIterator(), "image/png"); w.Code != http.StatusNotAcceptable {
+		t.Fatal("Got:", w.Code, "Wanted:", http.StatusNotAcceptable)
+	}
+}
+
+func TestChanIterator(t *testing.T) {
+	ch := make(chan *collectionItem, 3)
+	for i := 1; i <= 3; i++ {
+		ch <- &collectionItem{i}
+	}
+	close(ch)
+
+	w := serveCollection(ChanIterator(ch), "application/x-ndjson")
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatal("Got:", len(lines), "lines. Wanted: 3")
+	}
+	for i, line := range lines {
+		var item collectionItem
+		if err := json.Unmarshal([]byte(line), &item); err != nil || item.ID != i+1 {
+			t.Fatal("unexpected line:", line, err)
+		}
+	}
+}
+
+func TestCollectionCodecs(t *testing.T) {
+	mux := NewMux()
+	mux.Codecs = NewCodecs(&Codec{
+		MediaTypes: []string{"application/vnd.items+json"},
+		Marshal: func(v interface{}) ([]byte, error) {
+			return []byte(`"item"`), nil
+		},
+	}, TextCodec)
+	mux.Get("/items", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return NewCollection(newSliceIterator(), testTimeReference, "items", 0), nil
+	})
+	var test = func(accept string, code int, contentType, body string) {
+		r, _ := http.NewRequest(Get, "http://www.example.com/items", nil)
+		r.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		if w.Code != code || (code == http.StatusOK && (w.Header().Get("Content-Type") != contentType || w.Body.String() != body)) {
+			t.Fatalf("%s Got: %d %q %q Wanted: %d %q %q", accept, w.Code, w.Header().Get("Content-Type"), w.Body.String(), code, contentType, body)
+		}
+	}
+	test("", http.StatusOK, "application/vnd.items+json", `["item","item","item"]`)
+	test("application/vnd.items+json", http.StatusOK, "application/vnd.items+json", `["item","item","item"]`)
+	test("application/x-ndjson", http.StatusOK, "application/x-ndjson", "{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n")
+	test("application/json", http.StatusNotAcceptable, "", "")
+}
+
+func TestCollectionNilItems(t *testing.T) {
+	var test = func(accept, body string) {
+		items := []interface{}{nil, &collectionItem{1}}
+		iter := IteratorFunc(func() (interface{}, error) {
+			if len(items) == 0 {
+				return nil, io.EOF
+			}
+			item := items[0]
+			items = items[1:]
+			return item, nil
+		})
+		w := serveCollection(iter, accept)
+		if w.Code != http.StatusOK || w.Body.String() != body {
+			t.Fatalf("%s Got: %d %q Wanted: %q", accept, w.Code, w.Body.String(), body)
+		}
+	}
+	test("application/json", `[null,{"id":1}]`)
+	test("application/x-ndjson", "null\n{\"id\":1}\n")
+	test("application/xml", `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+`<List><collectionItem><ID>1</ID></collectionItem></List>`)
+}
+
+func TestCollectionFlushInterval(t *testing.T) {
+	mux := NewMux()
+	if interval := mux.collectionFlushInterval(); interval != DefaultCollectionFlushInterval {
+		t.Fatal("Got:", interval, "Wanted:", DefaultCollectionFlushInterval)
+	}
+	mux.CollectionFlushInterval = time.Second
+	group := mux.Group("/v1")
+	if interval := group.collectionFlushInterval(); interval != time.Second {
+		t.Fatal("Got:", interval, "Wanted:", time.Second)
+	}
+	group.CollectionFlushInterval = -1
+	if interval := group.collectionFlushInterval(); interval != 0 {
+		t.Fatal("Got:", interval, "Wanted:", 0)
+	}
+}
+
+func TestCollectionFlushBlockingIterator(t *testing.T) {
+	items := make(chan *collectionItem, 1)
+	mux := NewMux()
+	mux.CollectionFlushInterval = 20 * time.Millisecond
+	mux.Get("/items", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return NewCollection(ChanIterator(items), testTimeReference, "", 0), nil
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	defer close(items)
+
+	// The iterator blocks after the first item, which is flushed anyway.
+	items <- &collectionItem{1}
+	lines := make(chan string, 1)
+	go func() {
+		r, _ := http.NewRequest(Get, server.URL+"/items", nil)
+		r.Header.Set("Accept", "application/x-ndjson")
+		resp, err := http.DefaultClient.Do(r)
+		if err != nil {
+			lines <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		line, _ := bufio.NewReader(resp.Body).ReadString('\n')
+		lines <- line
+	}()
+	select {
+	case line := <-lines:
+		if line != "{\"id\":1}\n" {
+			t.Fatal("Got:", line, "Wanted:", `{"id":1}`)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("first item was not flushed while the iterator blocked")
+	}
+}
@@ -49,6 +49,12 @@ func getCompressionFormat(b []byte, r *http.Request) string {
 		return ""
 	}
 
+	return getStreamCompressionFormat(r)
+}
+
+// getStreamCompressionFormat returns the compression format that will be used
+// for a payload of unknown length streamed in the response to r.
+func getStreamCompressionFormat(r *http.Request) string {
 	encoding := r.Header.Get("Accept-Encoding")
 	if strings.Contains(encoding, gzipCompression) {
 		return gzipCompression
@@ -61,27 +67,33 @@ func getCompressionFormat(b []byte, r *http.Request) string {
 
 // compressor defines the methods implements by a compression writer.
 type compressor interface {
-	io.Writer
+	io.WriteCloser
 	Flush() error
 	Reset(io.Writer)
 }
 
-// getCompressor returns a writer that can compress data written to it.
-func compress(format string, dest io.Writer, b []byte) (int, error) {
+// getCompressor returns a writer compressing the data written to it in dest,
+// which must be released with putCompressor once closed.
+func getCompressor(format string, dest io.Writer) (compressor, error) {
 	var writer compressor
 	switch format {
 	case gzipCompression:
 		writer = gZipCompressorPool.Get().(*gzip.Writer)
-		defer gZipCompressorPool.Put(writer)
 	case flateCompression:
 		writer = flateCompressorPool.Get().(*flate.Writer)
-		defer flateCompressorPool.Put(writer)
 	default:
-		return 0, errUnknownCompressionFormat
+		return nil, errUnknownCompressionFormat
 	}
-
 	writer.Reset(dest)
-	n, err := writer.Write(b)
-	writer.Flush()
-	return n, err
+	return writer, nil
+}
+
+// putCompressor returns writer to its pool.
+func putCompressor(writer compressor) {
+	switch writer.(type) {
+	case *gzip.Writer:
+		gZipCompressorPool.Put(writer)
+	case *flate.Writer:
+		flateCompressorPool.Put(writer)
+	}
 }
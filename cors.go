@@ -121,7 +121,7 @@ func (h *accessControlHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	} else {
 		if preflighter, implemented := h.endpoint.(Preflighter); implemented && strings.ToUpper(r.Method) == Options {
 			// If Options and endpoint implements Preflighter, call Preflight.
-			resp = preflighter.Preflight(req, getVars(r), r)
+			resp = preflighter.Preflight(req, Vars(r), r)
 		} else {
 			resp = h.AccessControlResponse
 		}
@@ -6,20 +6,15 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"io/ioutil"
+	"mime"
 	"net/http"
+	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
-var alternatives = []string{
-	"application/json",
-	"text/javascript",
-	"application/xml",
-	"text/xml",
-	"text/plain",
-	"*/*",
-}
-
 /*
 Marshaler is implemented by resources wishing to handle their encoding
 on their own.
@@ -55,11 +50,9 @@ var jsonNull = []byte("null")
 // MarshalResource negotiates contentType based on the Accept header in r, and returns
 // the encoded version of resource as an array of bytes.
 //
-// MarshalResource can encode a resource in JSON and XML, as well as text using either
-// encoding.TextMarshaler or fmt.Stringer.
-//
-// MarshalResource's XML marshaling will always return a valid XML document with a
-// header and a root object, which is not the case for the encoding/xml package.
+// MarshalResource uses the codecs registered in the Mux serving r, or
+// DefaultCodecs, which can encode a resource in JSON and XML, as well as text
+// using either encoding.TextMarshaler or fmt.Stringer.
 //
 // MarshalResource can be called from Marshaler.MarshalRST on the same resource safely.
 func MarshalResource(resource interface{}, r *http.Request) (contentType string, encoded []byte, err error) {
@@ -73,26 +66,32 @@ func MarshalResource(resource interface{}, r *http.Request) (contentType string,
 		})
 	}
 
-	switch accept.Negotiate(alternatives...) {
-	case "application/json", "text/javascript":
-		b, err := json.Marshal(resource)
-		if bytes.Equal(b, jsonNull) {
-			b = []byte{}
-		}
-		return "application/json; charset=utf-8", b, err
-	case "application/xml", "text/xml":
-		b, err := marshalXML(resource)
-		return "application/xml; charset=utf-8", b, err
-	case "text/plain":
-		if marshaler, implemented := resource.(encoding.TextMarshaler); implemented {
-			b, err := marshaler.MarshalText()
-			return "text/plain; charset=utf-8", b, err
-		}
-		if marshaler, implemented := resource.(fmt.Stringer); implemented {
-			return "text/plain; charset=utf-8", []byte(marshaler.String()), nil
-		}
+	codec := getCodecs(r).Negotiate(accept)
+	if codec == nil && RequestPreferences(r).Handling == "lenient" {
+		// Clients preferring lenient handling receive the preferred format
+		// rather than an error.
+		codec = getCodecs(r).Negotiate(Accept{{Type: "*", SubType: "*", Params: make(map[string]string), Q: 1.0}})
+	}
+	if codec == nil {
+		return "", nil, NotAcceptable()
+	}
+	b, err := codec.Marshal(resource)
+	return codec.contentType(), b, err
+}
+
+// negotiatedLeniently returns true if contentType, the type of a
+// representation returned by MarshalResource, was chosen despite the Accept
+// header of r because the client prefers lenient handling.
+func negotiatedLeniently(contentType string, r *http.Request) bool {
+	if RequestPreferences(r).Handling != "lenient" {
+		return false
 	}
-	return "", nil, NotAcceptable()
+	accept := ParseAccept(r.Header.Get("Accept"))
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = contentType
+	}
+	return len(accept) > 0 && accept.Negotiate(mediaType) == ""
 }
 
 // marshalXML adds an XML header and an envelope when needed to the result
@@ -134,3 +133,231 @@ func Marshal(resource interface{}, r *http.Request) (contentType string, encoded
 
 	return MarshalResource(resource, r)
 }
+
+/*
+Unmarshaler is implemented by types wishing to decode the entity of a request
+on their own.
+
+	type Avatar []byte
+
+	// UnmarshalRST reads the raw bytes of a PNG image, and relies on
+	// rst.UnmarshalResource for the other cases.
+	func (a *Avatar) UnmarshalRST(r *http.Request) error {
+		if r.Header.Get("Content-Type") == "image/png" {
+			b, err := ioutil.ReadAll(r.Body)
+			*a = b
+			return err
+		}
+		return rst.UnmarshalResource(r, a)
+	}
+*/
+type Unmarshaler interface {
+	// UnmarshalRST must decode the entity in the body of the request, or
+	// return an error.
+	//
+	// UnmarshalRST is to rst.Unmarshal what UnmarshalJSON is to
+	// json.Unmarshal.
+	UnmarshalRST(*http.Request) error
+}
+
+// UnmarshalResource decodes the entity in the body of r into v, using the
+// codec that matches the Content-Type header of r.
+//
+// The codecs registered in the Mux serving r, or DefaultCodecs, are used. The
+// latter can decode JSON, XML, and form-urlencoded entities. An
+// UnsupportedMediaType error listing the supported types is returned for any
+// other format, and a BadRequest error indicating the position of the problem
+// is returned when the entity is malformed.
+//
+// UnmarshalResource can be called from Unmarshaler.UnmarshalRST on the same
+// value safely.
+func UnmarshalResource(r *http.Request, v interface{}) error {
+	codecs := getCodecs(r)
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return UnsupportedMediaType(codecs.Decodable()...)
+	}
+	codec := codecs.decoder(mediaType)
+	if codec == nil {
+		return UnsupportedMediaType(codecs.Decodable()...)
+	}
+
+	var body []byte
+	if r.Body != nil {
+		defer r.Body.Close()
+		if body, err = ioutil.ReadAll(r.Body); err != nil {
+			return BadRequest("", "Entity in the request could not be read.")
+		}
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return BadRequest("Entity in the request is empty", "")
+	}
+	return decodeError(codec.Unmarshal(body, v))
+}
+
+// Unmarshal decodes the entity in the body of r into v, based on the
+// Content-Type header of r.
+//
+// Unmarshal uses v.UnmarshalRST if v implements the Unmarshaler interface, or
+// UnmarshalResource if it doesn't. If v implements Validatable, it is then
+// validated, and an UnprocessableEntity error is returned with the violations
+// found.
+func Unmarshal(r *http.Request, v interface{}) error {
+	var err error
+	if unmarshaler, implemented := v.(Unmarshaler); implemented {
+		err = unmarshaler.UnmarshalRST(r)
+	} else {
+		err = UnmarshalResource(r, v)
+	}
+	if err != nil {
+		return err
+	}
+
+	if validatable, implemented := v.(Validatable); implemented {
+		validator := new(Validator)
+		validatable.Validate(validator)
+		return validator.Err()
+	}
+	return nil
+}
+
+// decodeError converts the errors returned by the decoders of the standard
+// library into a BadRequest error indicating where the problem is.
+func decodeError(err error) error {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *json.SyntaxError:
+		return BadRequest("", fmt.Sprintf("Syntax error at offset %d: %s.", e.Offset, e))
+	case *json.UnmarshalTypeError:
+		return BadRequest("", fmt.Sprintf("Cannot decode %s into a value of type %s at offset %d.", e.Value, e.Type, e.Offset))
+	case *xml.SyntaxError:
+		return BadRequest("", fmt.Sprintf("Syntax error on line %d: %s.", e.Line, e.Msg))
+	case *formError:
+		return BadRequest("", fmt.Sprintf("Cannot decode field %s: %s.", e.Key, e.Err))
+	}
+	return BadRequest("", fmt.Sprintf("Entity could not be decoded: %s.", err))
+}
+
+// formError is returned when a form value can't be stored in a field.
+type formError struct {
+	Key string
+	Err error
+}
+
+func (e *formError) Error() string {
+	return fmt.Sprintf("form field %s: %s", e.Key, e.Err)
+}
+
+/*
+unmarshalForm stores values in v, which must be a pointer to a url.Values, a
+map of strings, a map of string slices, or a struct.
+
+The fields of a struct are matched against the form keys using the "form" tag
+of the field, or its name if the tag is missing. Fields tagged with "-" are
+ignored.
+
+	type Person struct {
+		Name string   `form:"name"`
+		Age  int      `form:"age"`
+		Tags []string `form:"tag"`
+	}
+*/
+func unmarshalForm(values url.Values, v interface{}) error {
+	switch m := v.(type) {
+	case *url.Values:
+		*m = values
+		return nil
+	case *map[string][]string:
+		*m = values
+		return nil
+	case *map[string]string:
+		*m = make(map[string]string, len(values))
+		for key := range values {
+			(*m)[key] = values.Get(key)
+		}
+		return nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("cannot decode a form into a value of type %T", v)
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		key := field.Tag.Get("form")
+		if key == "-" {
+			continue
+		}
+		if key == "" {
+			key = field.Name
+		}
+		raw, ok := values[key]
+		if !ok || len(raw) == 0 {
+			continue
+		}
+		if err := setFormValue(rv.Field(i), raw); err != nil {
+			return &formError{Key: key, Err: err}
+		}
+	}
+	return nil
+}
+
+// setFormValue parses raw and stores the result in value.
+func setFormValue(value reflect.Value, raw []string) error {
+	if value.CanAddr() {
+		if unmarshaler, ok := value.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return unmarshaler.UnmarshalText([]byte(raw[0]))
+		}
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		return setFormValue(value.Elem(), raw)
+	case reflect.Slice:
+		slice := reflect.MakeSlice(value.Type(), len(raw), len(raw))
+		for i := range raw {
+			if err := setFormValue(slice.Index(i), raw[i:i+1]); err != nil {
+				return err
+			}
+		}
+		value.Set(slice)
+	case reflect.String:
+		value.SetString(raw[0])
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw[0])
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw[0], 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw[0], 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw[0], value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"mime"
 	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -142,3 +143,90 @@ func TestMarshalResource(t *testing.T) {
 		t.Fatal("Got:", string(b), "Wanted: hello, world!")
 	}
 }
+
+func newEntityRequest(contentType, body string) *http.Request {
+	r, _ := http.NewRequest(Post, "http://www.example.com/people", strings.NewReader(body))
+	r.Header.Set("Content-Type", contentType)
+	return r
+}
+
+// Testing whether Unmarshal picks the right decoder based on the
+// Content-Type header of the request.
+func TestUnmarshal(t *testing.T) {
+	var test = func(contentType, body string) {
+		var p person
+		if err := Unmarshal(newEntityRequest(contentType, body), &p); err != nil {
+			t.Fatal(contentType, err)
+		}
+		if p.Firstname != "Francis" || p.Age != 55 {
+			t.Errorf("%s: decoded %+v", contentType, p)
+		}
+	}
+
+	test("application/json", `{"firstname": "Francis", "age": 55}`)
+	test("application/json; charset=utf-8", `{"firstname": "Francis", "age": 55}`)
+	test("application/xml", `<person><Firstname>Francis</Firstname><Age>55</Age></person>`)
+	test("application/x-www-form-urlencoded", "Firstname=Francis&Age=55")
+}
+
+func TestUnmarshalForm(t *testing.T) {
+	var form struct {
+		Name    string   `form:"name"`
+		Age     *int     `form:"age"`
+		Tags    []string `form:"tag"`
+		Ignored string   `form:"-"`
+	}
+	r := newEntityRequest("application/x-www-form-urlencoded", "name=Francis&age=55&tag=a&tag=b&Ignored=x")
+	if err := Unmarshal(r, &form); err != nil {
+		t.Fatal(err)
+	}
+	if form.Name != "Francis" || form.Age == nil || *form.Age != 55 || len(form.Tags) != 2 || form.Ignored != "" {
+		t.Errorf("decoded %+v", form)
+	}
+
+	r = newEntityRequest("application/x-www-form-urlencoded", "name=Francis&age=unknown")
+	if e, valid := Unmarshal(r, &form).(*Error); !valid || e.Code != http.StatusBadRequest {
+		t.Errorf("Expecting error with code %d. Got: %v", http.StatusBadRequest, e)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	var test = func(contentType, body string, code int, position string) {
+		var p person
+		err := Unmarshal(newEntityRequest(contentType, body), &p)
+		e, valid := err.(*Error)
+		if !valid || e.Code != code {
+			t.Fatalf("Expecting error with code %d. Got: %v", code, err)
+		}
+		if !strings.Contains(e.Description, position) {
+			t.Errorf("Expecting %q in description. Got: %s", position, e.Description)
+		}
+	}
+
+	test("image/png", "blabla", http.StatusUnsupportedMediaType, "application/json")
+	test("", "blabla", http.StatusUnsupportedMediaType, "application/x-www-form-urlencoded")
+	test("application/json", "", http.StatusBadRequest, "")
+	test("application/json", `{"firstname": "Francis",, "age": 55}`, http.StatusBadRequest, "offset 25")
+	test("application/json", `{"age": "55"}`, http.StatusBadRequest, "int")
+	test("application/xml", "<person>\n<Firstname>Francis</Lastname></person>", http.StatusBadRequest, "line 2")
+}
+
+type customUnmarshaler struct {
+	content string
+}
+
+func (c *customUnmarshaler) UnmarshalRST(r *http.Request) error {
+	c.content = "hello, world!"
+	return nil
+}
+
+// Testing whether Unmarshal handles the Unmarshaler interface correctly.
+func TestUnmarshaler(t *testing.T) {
+	c := &customUnmarshaler{}
+	if err := Unmarshal(newEntityRequest("image/png", ""), c); err != nil {
+		t.Fatal(err)
+	}
+	if c.content != "hello, world!" {
+		t.Fatal("Got:", c.content, "Wanted: hello, world!")
+	}
+}
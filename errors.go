@@ -2,12 +2,15 @@ package rst
 
 import (
 	"bytes"
+	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"html/template"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"runtime"
+	"sort"
 	"strings"
 
 	"github.com/mohamedattahri/rst/internal/assets"
@@ -133,19 +136,62 @@ func UnsupportedMediaType(mimes ...string) *Error {
 	return err
 }
 
+// UnprocessableEntity is returned when the entity in the request is well-formed
+// but can't be processed because of semantic errors, like invalid values in some
+// of its fields.
+func UnprocessableEntity(violations ...*Violation) *Error {
+	err := NewError(
+		http.StatusUnprocessableEntity,
+		"Entity inside request could not be processed",
+		"The entity in the request is well-formed, but contains invalid values.",
+	)
+	err.Violations = violations
+	return err
+}
+
+// PreconditionRequired is returned when a request that modifies a resource is
+// required to be conditional, and contains none of the If-Match,
+// If-Unmodified-Since or If-None-Match headers.
+func PreconditionRequired() *Error {
+	err := NewError(
+		http.StatusPreconditionRequired,
+		"Request must be conditional",
+		"This resource can only be modified with requests containing an If-Match, If-Unmodified-Since or If-None-Match header.",
+	)
+	return err
+}
+
 // RequestedRangeNotSatisfiable is returned when the range in the Range header
-// does not overlap the current extent of the requested resource.
+// does not overlap the current extent of the requested resource. Only the unit
+// of cr and its total are written in the Content-Range header, as in
+// "bytes */1024".
 func RequestedRangeNotSatisfiable(cr *ContentRange) *Error {
 	err := NewError(
 		http.StatusRequestedRangeNotSatisfiable,
 		http.StatusText(http.StatusRequestedRangeNotSatisfiable),
 		"The requested range is not available and cannot be served.",
 	)
-	err.Header.Set("Content-Range", cr.String())
+	value := fmt.Sprintf("*/%d", cr.Total)
+	if cr.Range != nil && cr.Unit != "" {
+		value = cr.Unit + " " + value
+	}
+	err.Header.Set("Content-Range", value)
 	addVary(err.Header, "Range")
 	return err
 }
 
+// Violation describes why the value of a field in the entity of a request is
+// invalid.
+type Violation struct {
+	Path    string `json:"path" xml:"Path"`       // JSON pointer (RFC 6901) to the field.
+	Code    string `json:"code" xml:"Code"`       // Machine-readable identifier of the problem.
+	Message string `json:"message" xml:"Message"` // Human-readable explanation of the problem.
+}
+
+func (v *Violation) String() string {
+	return fmt.Sprintf("%s: %s (%s)", v.Path, v.Message, v.Code)
+}
+
 type stackRecord struct {
 	Filename string `json:"file" xml:"File"`
 	Line     int    `json:"line" xml:"Line"`
@@ -183,18 +229,54 @@ func InternalServerError(reason, description string, captureStack bool) *Error {
 	return err
 }
 
+// ServiceUnavailable is returned when the server is temporarily unable to
+// handle the request, for instance because it was canceled before it could be
+// processed.
+func ServiceUnavailable() *Error {
+	err := NewError(
+		http.StatusServiceUnavailable,
+		"Service is unavailable",
+		"The server is currently unable to handle the request. Please try again later.",
+	)
+	return err
+}
+
+// GatewayTimeout is returned when the server could not get a timely response
+// from the backends it relies on to process the request.
+func GatewayTimeout() *Error {
+	err := NewError(
+		http.StatusGatewayTimeout,
+		"Request timed out",
+		"The server did not receive a timely response from the backends needed to complete the request.",
+	)
+	return err
+}
+
 // Error represents an HTTP error, with a status code, a reason and a
 // description.
 // Error implements both the error and http.Handler interfaces.
 //
 // Header can be used to specify headers that will be written in the HTTP
 // response generated from this error.
+//
+// Errors are rendered as problem details (RFC 7807) when the client accepts
+// application/problem+json or application/problem+xml. Type, Instance and
+// Extensions can be used to enrich that rendering:
+//
+//	err := rst.NewError(http.StatusForbidden, "You do not have enough credit.", "Your current balance is 30, but that costs 50.")
+//	err.Type = "https://example.com/probs/out-of-credit"
+//	err.Instance = "/account/12345/msgs/abc"
+//	err.Extensions["balance"] = 30
 type Error struct {
-	Code        int            `json:"-" xml:"-"`
-	Header      http.Header    `json:"-" xml:"-"`
-	Reason      string         `json:"message" xml:"Message"`
-	Description string         `json:"description,omitempty" xml:"Description,omitempty"`
-	Stack       []*stackRecord `json:"stack,omitempty" xml:"Stack,omitempty"`
+	Code        int                    `json:"-" xml:"-"`
+	Header      http.Header            `json:"-" xml:"-"`
+	Type        string                 `json:"-" xml:"-"` // URI identifying the problem type. "about:blank" if empty.
+	Instance    string                 `json:"-" xml:"-"` // URI identifying this occurrence of the problem.
+	Extensions  map[string]interface{} `json:"-" xml:"-"` // Additional members of the problem details.
+	Reason      string                 `json:"message" xml:"Message"`
+	Description string                 `json:"description,omitempty" xml:"Description,omitempty"`
+	Violations  []*Violation           `json:"violations,omitempty" xml:"Violations>Violation,omitempty"`
+	Stack       []*stackRecord         `json:"stack,omitempty" xml:"Stack,omitempty"`
 }
 
 func (e *Error) Error() string {
@@ -208,6 +290,10 @@ func (e *Error) String() string {
 		s += fmt.Sprintf("\n%s", e.Description)
 	}
 
+	for _, v := range e.Violations {
+		s += fmt.Sprintf("\n- %s", v)
+	}
+
 	if e.Stack != nil && len(e.Stack) > 0 {
 		s += "\n"
 		for _, r := range e.Stack {
@@ -223,11 +309,150 @@ func (e *Error) StatusText() string {
 	return http.StatusText(e.Code)
 }
 
-// MarshalRST is implemented to generate an HTML rendering of the error.
+// Media types of the problem details (RFC 7807) renderings of an Error.
+const (
+	problemJSON = "application/problem+json"
+	problemXML  = "application/problem+xml"
+)
+
+// problemMembers returns the members of the problem details object
+// representing e, by order of appearance. Extensions can't override the
+// standard members.
+func (e *Error) problemMembers() (keys []string, members map[string]interface{}) {
+	problemType := e.Type
+	if problemType == "" {
+		problemType = "about:blank"
+	}
+	keys = []string{"type", "title", "status"}
+	members = map[string]interface{}{
+		"type":   problemType,
+		"title":  e.Reason,
+		"status": e.Code,
+	}
+	if e.Description != "" {
+		keys = append(keys, "detail")
+		members["detail"] = e.Description
+	}
+	if e.Instance != "" {
+		keys = append(keys, "instance")
+		members["instance"] = e.Instance
+	}
+
+	var extensions []string
+	for key := range e.Extensions {
+		if _, exists := members[key]; !exists {
+			extensions = append(extensions, key)
+		}
+	}
+	sort.Strings(extensions)
+	for _, key := range extensions {
+		members[key] = e.Extensions[key]
+	}
+	keys = append(keys, extensions...)
+
+	if _, exists := members["violations"]; !exists && len(e.Violations) > 0 {
+		keys = append(keys, "violations")
+		members["violations"] = e.Violations
+	}
+	if _, exists := members["stack"]; !exists && len(e.Stack) > 0 {
+		keys = append(keys, "stack")
+		members["stack"] = e.Stack
+	}
+	return keys, members
+}
+
+// marshalProblemJSON returns the application/problem+json rendering of e.
+func (e *Error) marshalProblemJSON() ([]byte, error) {
+	_, members := e.problemMembers()
+	return json.Marshal(members)
+}
+
+// marshalProblemXML returns the application/problem+xml rendering of e, as
+// described in appendix A of RFC 7807.
+func (e *Error) marshalProblemXML() ([]byte, error) {
+	buffer := bytes.NewBufferString(xml.Header)
+	encoder := xml.NewEncoder(buffer)
+	root := xml.StartElement{Name: xml.Name{Space: "urn:ietf:rfc:7807", Local: "problem"}}
+	if err := encoder.EncodeToken(root); err != nil {
+		return nil, err
+	}
+	keys, members := e.problemMembers()
+	for _, key := range keys {
+		// Members are converted to their JSON model first, so that both
+		// renderings carry the same names and structure.
+		b, err := json.Marshal(members[key])
+		if err != nil {
+			return nil, err
+		}
+		decoder := json.NewDecoder(bytes.NewReader(b))
+		decoder.UseNumber()
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		if err := encodeProblemXML(encoder, key, value); err != nil {
+			return nil, err
+		}
+	}
+	if err := encoder.EncodeToken(root.End()); err != nil {
+		return nil, err
+	}
+	if err := encoder.Flush(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// encodeProblemXML encodes value, decoded from JSON, in an element called name
+// following the rules of appendix A of RFC 7807: the members of objects are
+// child elements, and the items of arrays are child elements called "i".
+func encodeProblemXML(encoder *xml.Encoder, name string, value interface{}) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if err := encoder.EncodeToken(start); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case nil:
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if err := encodeProblemXML(encoder, key, v[key]); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if err := encodeProblemXML(encoder, "i", item); err != nil {
+				return err
+			}
+		}
+	default:
+		if err := encoder.EncodeToken(xml.CharData(fmt.Sprint(v))); err != nil {
+			return err
+		}
+	}
+	return encoder.EncodeToken(start.End())
+}
+
+// MarshalRST is implemented to generate an HTML rendering of the error when
+// preferred by the client, a problem details (RFC 7807) rendering when
+// accepted, and relies on the registered codecs otherwise.
 func (e *Error) MarshalRST(r *http.Request) (string, []byte, error) {
 	accept := ParseAccept(r.Header.Get("Accept"))
-	ct := accept.Negotiate("text/html", "*/*")
-	if strings.Contains(ct, "html") || ct == "*/*" {
+	ct := accept.Negotiate(append([]string{"text/html", problemJSON, problemXML}, getCodecs(r).Encodable()...)...)
+	switch ct {
+	case problemJSON:
+		b, err := e.marshalProblemJSON()
+		return problemJSON, b, err
+	case problemXML:
+		b, err := e.marshalProblemXML()
+		return problemXML, b, err
+	}
+	if ct == "text/html" {
 		buffer := &bytes.Buffer{}
 		var data = struct {
 			Request *http.Request
@@ -281,6 +506,7 @@ func NewError(code int, reason, description string) *Error {
 		Reason:      reason,
 		Description: description,
 		Header:      make(http.Header),
+		Extensions:  make(map[string]interface{}),
 	}
 }
 
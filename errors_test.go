@@ -2,9 +2,12 @@ package rst
 
 import (
 	"bytes"
+	"encoding/json"
+	"encoding/xml"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -57,3 +60,85 @@ func TestInternalServerErrorStackDisplay(t *testing.T) {
 		t.Fatalf("provoked panic with Debug=False did not log message correctly: %s", buffer.String())
 	}
 }
+
+func TestErrorProblemDetails(t *testing.T) {
+	e := NewError(http.StatusForbidden, "You do not have enough credit.", "Your current balance is 30, but that costs 50.")
+	e.Type = "https://example.com/probs/out-of-credit"
+	e.Instance = "/account/12345/msgs/abc"
+	e.Extensions["balance"] = 30
+	e.Extensions["status"] = 200 // standard members can't be overridden
+	e.Extensions["accounts"] = []interface{}{"/account/12345", map[string]interface{}{"id": 67890, "tags": []string{"a", "b"}}}
+
+	var test = func(accept, expectedType string) []byte {
+		r, _ := http.NewRequest(Get, "http://www.example.com/account/12345/msgs/abc", nil)
+		r.Header.Set("Accept", accept)
+		ct, b, err := e.MarshalRST(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ct != expectedType {
+			t.Fatal("Got:", ct, "Wanted:", expectedType)
+		}
+		return b
+	}
+
+	var problem map[string]interface{}
+	if err := json.Unmarshal(test("application/problem+json", "application/problem+json"), &problem); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"type":     e.Type,
+		"title":    e.Reason,
+		"status":   float64(http.StatusForbidden),
+		"detail":   e.Description,
+		"instance": e.Instance,
+		"balance":  float64(30),
+		"accounts": []interface{}{"/account/12345", map[string]interface{}{"id": float64(67890), "tags": []interface{}{"a", "b"}}},
+	}
+	if !reflect.DeepEqual(problem, expected) {
+		t.Fatal("Got:", problem, "Wanted:", expected)
+	}
+
+	var xmlProblem struct {
+		XMLName xml.Name `xml:"urn:ietf:rfc:7807 problem"`
+		Type    string   `xml:"type"`
+		Title   string   `xml:"title"`
+		Status  int      `xml:"status"`
+		Balance int      `xml:"balance"`
+	}
+	if err := xml.Unmarshal(test("application/problem+xml", "application/problem+xml"), &xmlProblem); err != nil {
+		t.Fatal(err)
+	}
+	if xmlProblem.Type != e.Type || xmlProblem.Title != e.Reason || xmlProblem.Status != e.Code || xmlProblem.Balance != 30 {
+		t.Fatalf("unexpected XML rendering %+v", xmlProblem)
+	}
+	nested := "<accounts><i>/account/12345</i><i><id>67890</id><tags><i>a</i><i>b</i></tags></i></accounts>"
+	if b := test("application/problem+xml", "application/problem+xml"); !bytes.Contains(b, []byte(nested)) {
+		t.Fatal("Got:", string(b), "Wanted:", nested)
+	}
+
+	// Browsers still get HTML, and other clients the registered codecs.
+	test("text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", "text/html; charset=utf-8")
+	test("application/json", "application/json; charset=utf-8")
+}
+
+func TestErrorProblemDefaults(t *testing.T) {
+	header := make(http.Header)
+	header.Set("Accept", "application/problem+json")
+	rr := newRequestResponse(Get, testServerAddr+"/people/blablabla", header, nil)
+	if err := rr.TestStatusCode(http.StatusNotFound); err != nil {
+		t.Fatal(err)
+	}
+	if err := rr.TestHeader("Content-Type", "application/problem+json"); err != nil {
+		t.Fatal(err)
+	}
+
+	var problem map[string]interface{}
+	if err := json.NewDecoder(rr.resp.Body).Decode(&problem); err != nil {
+		t.Fatal(err)
+	}
+	rr.resp.Body.Close()
+	if problem["type"] != "about:blank" || problem["status"] != float64(http.StatusNotFound) {
+		t.Fatal("unexpected problem details:", problem)
+	}
+}
@@ -0,0 +1,237 @@
+package rst
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// eventStream is the media type of streams of Server-Sent Events.
+const eventStream = "text/event-stream"
+
+// DefaultEventHeartbeat is the interval at which a comment is sent to the
+// clients of an EventSource when no event was sent, to keep the connection open
+// through proxies, unless the EventHeartbeat field of the mux is set.
+const DefaultEventHeartbeat = 15 * time.Second
+
+// eventFieldReplacer removes the line breaks that would end a field of an
+// event early.
+var eventFieldReplacer = strings.NewReplacer("\r", "", "\n", "")
+
+// Event is a message pushed to the clients of an EventSource.
+//
+// Data is encoded with the codec negotiated with the other media types listed
+// in the Accept header of the request, or with the preferred codec of the mux.
+// Strings and byte slices are sent as they are.
+type Event struct {
+	ID    string        // Identifier sent back by clients in the Last-Event-ID header when they reconnect.
+	Event string        // Type of the event. Clients assume "message" if empty.
+	Data  interface{}   // Payload of the event.
+	Retry time.Duration // Time clients wait before they reconnect. Unchanged if 0.
+}
+
+/*
+EventSource is implemented by endpoints pushing Server-Sent Events to clients.
+It serves GET requests accepting text/event-stream, while other GET requests
+are still served by the Getter of the endpoint, if any.
+
+	func (ep *PriceEP) Events(ctx context.Context, vars rst.RouteVars, r *http.Request) (<-chan *rst.Event, error) {
+		events := make(chan *rst.Event)
+		go func() {
+			defer close(events)
+			for price := range ep.feed.Since(rst.LastEventID(r)) {
+				select {
+				case events <- &rst.Event{ID: price.ID, Event: "price", Data: price}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		return events, nil
+	}
+
+The stream ends when the channel is closed. ctx is done when the client
+disconnects, after which nothing is read from the channel anymore.
+*/
+type EventSource interface {
+	Events(context.Context, RouteVars, *http.Request) (<-chan *Event, error)
+}
+
+// LastEventID returns the identifier of the last event received by a client
+// reconnecting to an EventSource, found in the Last-Event-ID header of r or in
+// its lastEventId query parameter.
+func LastEventID(r *http.Request) string {
+	if id := r.Header.Get("Last-Event-ID"); id != "" {
+		return id
+	}
+	return r.URL.Query().Get("lastEventId")
+}
+
+// acceptsEvents returns true if header explicitly accepts a stream of
+// Server-Sent Events.
+func acceptsEvents(header http.Header) bool {
+	if header == nil {
+		return false
+	}
+	for _, clause := range ParseAccept(header.Get("Accept")) {
+		if clause.Q > 0 && strings.EqualFold(clause.Type+"/"+clause.SubType, eventStream) {
+			return true
+		}
+	}
+	return false
+}
+
+// eventCodec returns the codec encoding the data of the events sent in
+// response to r.
+func eventCodec(r *http.Request) *Codec {
+	var accept Accept
+	for _, clause := range ParseAccept(r.Header.Get("Accept")) {
+		if !strings.EqualFold(clause.Type+"/"+clause.SubType, eventStream) {
+			accept = append(accept, clause)
+		}
+	}
+	codecs := getCodecs(r)
+	if codec := codecs.Negotiate(accept); codec != nil {
+		return codec
+	}
+	return codecs.Negotiate(Accept{{Type: "*", SubType: "*", Params: make(map[string]string), Q: 1.0}})
+}
+
+// eventHeartbeat returns the interval of the heartbeats of the event streams
+// served in response to r. Heartbeats are disabled if 0.
+func eventHeartbeat(r *http.Request) time.Duration {
+	if route, _ := r.Context().Value(routeKey).(*Route); route != nil {
+		return route.mux.eventHeartbeat()
+	}
+	return DefaultEventHeartbeat
+}
+
+// writeEvent writes e in w, in the format defined by the Server-Sent Events
+// specification.
+func writeEvent(w io.Writer, e *Event, codec *Codec) error {
+	var data []byte
+	switch v := e.Data.(type) {
+	case nil:
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		if codec == nil {
+			return NotAcceptable()
+		}
+		b, err := codec.Marshal(v)
+		if err != nil {
+			return err
+		}
+		data = b
+	}
+
+	var buf bytes.Buffer
+	if e.ID != "" {
+		fmt.Fprintf(&buf, "id: %s\n", eventFieldReplacer.Replace(e.ID))
+	}
+	if e.Event != "" {
+		fmt.Fprintf(&buf, "event: %s\n", eventFieldReplacer.Replace(e.Event))
+	}
+	if e.Retry > 0 {
+		fmt.Fprintf(&buf, "retry: %d\n", e.Retry/time.Millisecond)
+	}
+	for _, line := range bytes.Split(bytes.TrimRight(data, "\n"), []byte("\n")) {
+		buf.WriteString("data: ")
+		buf.Write(bytes.TrimSuffix(line, []byte("\r")))
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// EventSourceFunc allows an EventSource.Events method to be used as an
+// http.Handler.
+type EventSourceFunc func(context.Context, RouteVars, *http.Request) (<-chan *Event, error)
+
+// ServeHTTP implements the http.Handler interface.
+func (f EventSourceFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !acceptsEvents(r.Header) {
+		writeError(NotAcceptable(), w, r)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	var events <-chan *Event
+	if strings.ToUpper(r.Method) != Head {
+		var err error
+		if events, err = f(ctx, Vars(r), r); err != nil {
+			writeError(err, w, r)
+			return
+		}
+	}
+
+	streamResponse(w)
+	w.Header().Set("Content-Type", eventStream)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Del("Content-Encoding")
+	addVary(w.Header(), "Accept")
+	w.WriteHeader(http.StatusOK)
+	if events == nil {
+		w.Write(noContent)
+		return
+	}
+
+	flusher, _ := w.(http.Flusher)
+	flush := func() {
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	flush()
+
+	// Heartbeats are only sent after an idle interval, which starts over
+	// every time something is written.
+	var (
+		interval  = eventHeartbeat(r)
+		timer     *time.Timer
+		heartbeat <-chan time.Time
+	)
+	if interval > 0 {
+		timer = time.NewTimer(interval)
+		defer timer.Stop()
+		heartbeat = timer.C
+	}
+
+	codec := eventCodec(r)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat:
+			if _, err := io.WriteString(w, ":\n\n"); err != nil {
+				return
+			}
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			if e == nil {
+				continue
+			}
+			if err := writeEvent(w, e, codec); err != nil {
+				return
+			}
+			if timer != nil && !timer.Stop() {
+				<-timer.C
+			}
+		}
+		flush()
+		if timer != nil {
+			timer.Reset(interval)
+		}
+	}
+}
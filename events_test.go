@@ -0,0 +1,180 @@
+package rst
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteEvent(t *testing.T) {
+	var test = func(e *Event, expected string) {
+		var buf bytes.Buffer
+		if err := writeEvent(&buf, e, JSONCodec); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != expected {
+			t.Fatalf("Got: %q Wanted: %q", buf.String(), expected)
+		}
+	}
+	test(&Event{Data: "hello"}, "data: hello\n\n")
+	test(&Event{ID: "42", Event: "update", Retry: 3 * time.Second, Data: "line 1\nline 2\n"}, "id: 42\nevent: update\nretry: 3000\ndata: line 1\ndata: line 2\n\n")
+	test(&Event{Data: map[string]int{"price": 10}}, "data: {\"price\":10}\n\n")
+	test(&Event{Event: "ping"}, "event: ping\ndata: \n\n")
+	test(&Event{ID: "4\r\n2", Event: "up\ndate", Data: "x"}, "id: 42\nevent: update\ndata: x\n\n")
+}
+
+type priceSource struct {
+	done chan struct{}
+}
+
+func (s *priceSource) Events(ctx context.Context, vars RouteVars, r *http.Request) (<-chan *Event, error) {
+	events := make(chan *Event)
+	go func() {
+		defer close(s.done)
+		events <- &Event{ID: "1", Event: "price", Data: map[string]string{"since": LastEventID(r)}}
+		<-ctx.Done()
+	}()
+	return events, nil
+}
+
+func TestEventSource(t *testing.T) {
+	source := &priceSource{done: make(chan struct{})}
+	mux := NewMux()
+	mux.EventHeartbeat = 10 * time.Millisecond
+	mux.Events("/prices", source.Events)
+	mux.Get("/prices", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return NewEnvelope("prices", testTimeReference, "", 0), nil
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	r, _ := http.NewRequest(Get, server.URL+"/prices", nil)
+	r.Header.Set("Accept", "text/event-stream")
+	r.Header.Set("Accept-Encoding", "gzip")
+	r.Header.Set("Last-Event-ID", "0")
+	resp, err := http.DefaultClient.Do(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" || resp.Header.Get("Content-Encoding") != "" {
+		t.Fatal("unexpected response:", resp.StatusCode, resp.Header)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	var lines []string
+	for len(lines) < 5 {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, strings.TrimSuffix(line, "\n"))
+	}
+	expected := []string{"id: 1", "event: price", `data: {"since":"0"}`, "", ":"}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Fatalf("Got: %q Wanted: %q", lines, expected)
+		}
+	}
+
+	resp.Body.Close()
+	select {
+	case <-source.done:
+	case <-time.After(time.Second):
+		t.Fatal("source was not stopped when the client disconnected")
+	}
+
+	// Requests that don't accept events are served by the getter.
+	resp, err = http.Get(server.URL + "/prices")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") == "text/event-stream" {
+		t.Fatal("unexpected response:", resp.StatusCode, resp.Header)
+	}
+}
+
+func TestEventHeartbeat(t *testing.T) {
+	mux := NewMux()
+	mux.EventHeartbeat = 200 * time.Millisecond
+	mux.Events("/ticks", func(ctx context.Context, vars RouteVars, r *http.Request) (<-chan *Event, error) {
+		events := make(chan *Event)
+		go func() {
+			defer close(events)
+			for i := 0; i < 10; i++ {
+				time.Sleep(20 * time.Millisecond)
+				select {
+				case events <- &Event{Data: "tick"}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		return events, nil
+	})
+	group := mux.Group("/quiet")
+	group.EventHeartbeat = -1
+	group.Events("/ticks", func(ctx context.Context, vars RouteVars, r *http.Request) (<-chan *Event, error) {
+		events := make(chan *Event)
+		go func() {
+			defer close(events)
+			time.Sleep(300 * time.Millisecond)
+		}()
+		return events, nil
+	})
+
+	var test = func(path string) string {
+		r, _ := http.NewRequest(Get, "http://www.example.com"+path, nil)
+		r.Header.Set("Accept", "text/event-stream")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w.Body.String()
+	}
+	// Events reset the heartbeat interval.
+	if body := test("/ticks"); strings.Contains(body, ":\n") || strings.Count(body, "data: tick\n") != 10 {
+		t.Fatalf("Got: %q Wanted: 10 events without heartbeats", body)
+	}
+	if body := test("/quiet/ticks"); body != "" {
+		t.Fatalf("Got: %q Wanted: no heartbeats", body)
+	}
+}
+
+type eventsOnlyEndpoint struct{}
+
+func (ep *eventsOnlyEndpoint) Events(ctx context.Context, vars RouteVars, r *http.Request) (<-chan *Event, error) {
+	events := make(chan *Event, 1)
+	events <- &Event{Data: "done"}
+	close(events)
+	return events, nil
+}
+
+func TestEventSourceEndpoint(t *testing.T) {
+	ep := &eventsOnlyEndpoint{}
+	if methods := AllowedMethods(ep); len(methods) != 2 || methods[0] != Head || methods[1] != Get {
+		t.Fatal("Got:", methods, "Wanted: [HEAD GET]")
+	}
+
+	mux := NewMux()
+	mux.Debug = true
+	mux.HandleEndpoint("/events", ep)
+
+	var test = func(accept string, code int, body string) {
+		r, _ := http.NewRequest(Get, "http://www.example.com/events", nil)
+		r.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		if w.Code != code {
+			t.Fatal(accept, "Got:", w.Code, "Wanted:", code)
+		}
+		if body != "" && w.Body.String() != body {
+			t.Fatalf("Got: %q Wanted: %q", w.Body.String(), body)
+		}
+	}
+	test("text/event-stream", http.StatusOK, "data: done\n\n")
+	test("application/json", http.StatusNotAcceptable, "")
+}
@@ -1,7 +1,13 @@
 package rst
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"strings"
 	"time"
 )
@@ -20,50 +26,124 @@ features:
 - The Ranger interface adds support for range requests and allows the resource to
 return partial responses.
 
+- The Linker interface adds links to related resources, such as the pages of a
+collection, in the Link header of responses.
+
 - The Marshaler interface allows you to customize the encoding process of the
 resource and control the bytes returned in the payload of the response.
 
+- The StreamMarshaler and Opener interfaces allow large representations to be
+streamed in the response instead of being buffered in memory.
+
 - The http.Handler interface can be used to gain direct access to the
 ResponseWriter and Request. This is a low level method that should only be used
 when you need to write chunked responses, or if you wish to add specific headers
 such a Content-Disposition, etc.
 */
 type Resource interface {
-	ETag() string            // ETag identifying the current version of the resource.
+	ETag() string            // ETag identifying the current version of the resource. Quoted automatically. See WeakETag.
 	LastModified() time.Time // Date and time of the last modification of the resource.
 	TTL() time.Duration      // Time to live, or caching duration of the resource.
 }
 
 /*
-ValidateConditions returns true if the If-Unmodified-Since or the If-Match headers of
-r are not matching with the current version of resource.
+CacheController is implemented by resources that need more control over the
+way they're cached than what TTL allows.
+
+	func (p *Person) CacheControl() *rst.CacheControl {
+		return &rst.CacheControl{Private: true, MaxAge: p.TTL(), StaleIfError: time.Hour}
+	}
+
+Responses of resources that don't implement it get a Cache-Control header with
+a max-age directive derived from TTL, unless the mux already sets one.
+*/
+type CacheController interface {
+	CacheControl() *CacheControl
+}
+
+/*
+ValidateConditions returns true if the conditional headers of r are not matching
+with the current version of resource. See EvaluateConditions.
 
 	func (ep *endpoint) Patch(vars RouteVars, r *http.Request) (Resource, error) {
 		resource := db.Lookup(vars.Get("id"))
 		if ValidateConditions(resource, r) {
-			return nil, Conflict()
+			return nil, PreconditionFailed()
 		}
 
 		// apply the patch safely from here
 	}
 */
 func ValidateConditions(resource Resource, r *http.Request) bool {
-	if d, err := time.Parse(rfc1123, r.Header.Get("If-Unmodified-Since")); err == nil {
-		if d.Sub(resource.LastModified()) < 0 {
-			return true
+	return EvaluateConditions(resource, r) == http.StatusPreconditionFailed
+}
+
+/*
+EvaluateConditions evaluates the If-Match, If-Unmodified-Since, If-None-Match
+and If-Modified-Since headers of r against the current version of resource, in
+the order of precedence defined in RFC 7232, section 6. A nil resource is
+considered as not existing.
+
+It returns http.StatusNotModified when a GET or HEAD request can be answered
+with a 304 Not Modified response, http.StatusPreconditionFailed when a condition
+fails, or 0 when the request can be processed.
+*/
+func EvaluateConditions(resource Resource, r *http.Request) int {
+	var (
+		etag         entityTag
+		lastModified time.Time
+	)
+	if resource != nil {
+		etag = parseETag(resource.ETag())
+		lastModified = resource.LastModified().UTC().Truncate(time.Second)
+	}
+	method := strings.ToUpper(r.Method)
+	safe := method == Get || method == Head
+
+	if raw := r.Header.Get("If-Match"); raw != "" {
+		tags, any := parseETags(raw)
+		if resource == nil || (!any && (etag.opaque == "" || !etag.matches(tags, false))) {
+			return http.StatusPreconditionFailed
+		}
+	} else if d, err := http.ParseTime(r.Header.Get("If-Unmodified-Since")); err == nil && !lastModified.IsZero() {
+		if lastModified.After(d) {
+			return http.StatusPreconditionFailed
 		}
 	}
-	if etag := r.Header.Get("If-Match"); etag != "" && etag != resource.ETag() {
-		return true
+
+	if raw := r.Header.Get("If-None-Match"); raw != "" {
+		tags, any := parseETags(raw)
+		if resource != nil && (any || (etag.opaque != "" && etag.matches(tags, true))) {
+			if safe {
+				return http.StatusNotModified
+			}
+			return http.StatusPreconditionFailed
+		}
+	} else if d, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && safe && !lastModified.IsZero() {
+		if !lastModified.After(d) {
+			return http.StatusNotModified
+		}
 	}
-	return false
+	return 0
+}
+
+// matchIfRange returns true if raw, the value of an If-Range header, matches
+// the current version of resource. Entity tags must match strongly.
+func matchIfRange(raw string, resource Resource) bool {
+	if d, err := http.ParseTime(raw); err == nil {
+		return d.Equal(resource.LastModified().UTC().Truncate(time.Second))
+	}
+	return parseETag(raw).strongMatch(parseETag(resource.ETag()))
 }
 
 /*
 Ranger is implemented by resources that support partial responses.
 
 Range will only be called if the request contains a valid Range header.
-Otherwise, it will be processed as a normal Get request.
+Otherwise, it will be processed as a normal Get request. When several ranges
+are requested, Range is called for each of them once they have been coalesced,
+and the parts are streamed in a multipart/byteranges response. Requests for more
+ranges than the MaxRanges of the mux are served in full.
 
 	type Doc []byte
 	// assuming Doc implements rst.Resource interface
@@ -101,29 +181,10 @@ func writeError(err error, w http.ResponseWriter, r *http.Request) {
 }
 
 func writeResource(resource Resource, w http.ResponseWriter, r *http.Request) {
-	// Time-based conditional retrieval
-	if t, err := time.Parse(rfc1123, r.Header.Get("If-Modified-Since")); err == nil {
-		if t.Sub(resource.LastModified()).Seconds() >= 0 {
-			w.WriteHeader(http.StatusNotModified)
-			w.Write(noContent)
-			return
-		}
-	}
-
-	// ETag-based conditional retrieval
-	for _, t := range strings.Split(r.Header.Get("If-None-Match"), ";") {
-		if t == resource.ETag() {
-			w.WriteHeader(http.StatusNotModified)
-			w.Write(noContent)
-			return
-		}
-	}
-
 	// Headers
 	addVary(w.Header(), "Accept")
-	w.Header().Set("Last-Modified", resource.LastModified().UTC().Format(rfc1123))
-	w.Header().Set("ETag", resource.ETag())
-	w.Header().Set("Expires", time.Now().Add(resource.TTL()).UTC().Format(rfc1123))
+	writeValidators(resource, w, r)
+	writeLinks(resource, w, r)
 
 	// If resource implements http.Handler, let it write in the ResponseWriter
 	// on its own.
@@ -132,6 +193,11 @@ func writeResource(resource Resource, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Streamed resources are written without being buffered in memory.
+	if writeStreamer(resource, w, r) {
+		return
+	}
+
 	var (
 		contentType string
 		b           []byte
@@ -142,12 +208,38 @@ func writeResource(resource Resource, w http.ResponseWriter, r *http.Request) {
 		writeError(err, w, r)
 		return
 	}
-	w.Header().Set("Content-Type", contentType)
+	writeRepresentation(resource, contentType, b, w, r)
+}
+
+// writeRepresentation writes b, the encoding of resource in contentType, with
+// the appropriate status code and headers.
+func writeRepresentation(resource Resource, contentType string, b []byte, w http.ResponseWriter, r *http.Request) {
+	compression := getCompressionFormat(b, r)
+	partial := w.Header().Get("Content-Range") != "" || strings.HasPrefix(contentType, "multipart/byteranges")
+
+	// Resources without an ETag are identified by the hash of their encoding
+	// when enabled in the mux. The tag is weak when the body is compressed.
+	if autoETag, _ := r.Context().Value(autoETagKey).(bool); autoETag && w.Header().Get("ETag") == "" && !partial {
+		etag := hashETag(b)
+		etag.weak = compression != ""
+		w.Header().Set("ETag", etag.String())
+
+		if method := strings.ToUpper(r.Method); method == Get || method == Head {
+			if tags, any := parseETags(r.Header.Get("If-None-Match")); any || etag.matches(tags, true) {
+				writeNotModified(resource, w, r)
+				return
+			}
+		}
+	}
 
-	if compression := getCompressionFormat(b, r); compression != "" {
+	w.Header().Set("Content-Type", contentType)
+	if compression != "" {
 		w.Header().Set("Content-Encoding", compression)
 		addVary(w.Header(), "Accept-Encoding")
 	}
+	if negotiatedLeniently(contentType, r) {
+		addPreferenceApplied(w.Header(), "handling=lenient")
+	}
 
 	if strings.ToUpper(r.Method) == Post {
 		w.WriteHeader(http.StatusCreated)
@@ -161,7 +253,7 @@ func writeResource(resource Resource, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if w.Header().Get("Content-Range") != "" {
+	if partial {
 		w.WriteHeader(http.StatusPartialContent)
 	} else {
 		w.WriteHeader(http.StatusOK)
@@ -174,6 +266,55 @@ func writeResource(resource Resource, w http.ResponseWriter, r *http.Request) {
 	w.Write(b)
 }
 
+// writeValidators writes the headers describing the current version of
+// resource.
+func writeValidators(resource Resource, w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Last-Modified", resource.LastModified().UTC().Format(rfc1123))
+	if etag := parseETag(resource.ETag()); etag.opaque != "" {
+		w.Header().Set("ETag", etag.String())
+	}
+	w.Header().Set("Expires", time.Now().Add(resource.TTL()).UTC().Format(rfc1123))
+
+	if controller, ok := resource.(CacheController); ok {
+		if cc := controller.CacheControl(); cc != nil {
+			w.Header().Set("Cache-Control", cc.String())
+			return
+		}
+	}
+	// The responses to unsafe methods are not made cacheable implicitly.
+	if method := strings.ToUpper(r.Method); method != Get && method != Head {
+		return
+	}
+	if ttl := resource.TTL(); ttl > 0 && w.Header().Get("Cache-Control") == "" {
+		w.Header().Set("Cache-Control", (&CacheControl{MaxAge: ttl}).String())
+	}
+}
+
+// writePreferredReturn applies the return preference of r to the response to a
+// write request. If a minimal response is preferred, it writes code without a
+// body, along with the validators of resource, and returns true.
+func writePreferredReturn(resource Resource, code int, w http.ResponseWriter, r *http.Request) bool {
+	switch RequestPreferences(r).Return {
+	case "minimal":
+		addPreferenceApplied(w.Header(), "return=minimal")
+		writeValidators(resource, w, r)
+		w.WriteHeader(code)
+		w.Write(noContent)
+		return true
+	case "representation":
+		addPreferenceApplied(w.Header(), "return=representation")
+	}
+	return false
+}
+
+// writeNotModified writes a 304 Not Modified response for resource.
+func writeNotModified(resource Resource, w http.ResponseWriter, r *http.Request) {
+	addVary(w.Header(), "Accept")
+	writeValidators(resource, w, r)
+	w.WriteHeader(http.StatusNotModified)
+	w.Write(noContent)
+}
+
 /*
 Endpoint represents an access point exposing a resource in the REST service.
 */
@@ -201,7 +342,7 @@ type GetFunc func(RouteVars, *http.Request) (Resource, error)
 
 // ServeHTTP implements the http.Handler interface.
 func (f GetFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	vars := getVars(r)
+	vars := Vars(r)
 
 	resource, err := f(vars, r)
 	if err != nil {
@@ -214,6 +355,15 @@ func (f GetFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	switch EvaluateConditions(resource, r) {
+	case http.StatusNotModified:
+		writeNotModified(resource, w, r)
+		return
+	case http.StatusPreconditionFailed:
+		writeError(PreconditionFailed(), w, r)
+		return
+	}
+
 	// Check if resource implements Ranger
 	ranger, implemented := resource.(Ranger)
 	if !implemented {
@@ -224,8 +374,17 @@ func (f GetFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// Check if request contains a valid Range header, and check whether it's
 	// a valid range.
-	rg, err := ParseRange(r.Header.Get("Range"))
-	if err != nil || rg.validate(ranger) != nil {
+	// Invalid ranges are ignored, unless the client prefers strict handling.
+	ranges, err := ParseRange(r.Header.Get("Range"))
+	if err == nil {
+		err = ranges.validate(ranger)
+	}
+	if err != nil {
+		if r.Header.Get("Range") != "" && RequestPreferences(r).Handling == "strict" {
+			addPreferenceApplied(w.Header(), "handling=strict")
+			writeError(BadRequest("Invalid Range header", fmt.Sprintf("The Range header could not be processed: %s.", err)), w, r)
+			return
+		}
 		writeResource(resource, w, r)
 		return
 	}
@@ -233,30 +392,93 @@ func (f GetFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// If-Range can either contain an ETag, or a date.
 	// If the precondition fails, the Range header is ignored and the full
 	// resource is returned.
-	if raw := r.Header.Get("If-Range"); raw != "" {
-		date, _ := time.Parse(rfc1123, raw)
-		if !date.Equal(resource.LastModified()) && raw != resource.ETag() {
-			writeResource(resource, w, r)
-			return
-		}
+	if raw := r.Header.Get("If-Range"); raw != "" && !matchIfRange(raw, resource) {
+		writeResource(resource, w, r)
+		return
 	}
 
-	if err := rg.adjust(ranger); err != nil {
+	ranges, err = ranges.adjust(ranger)
+	if err != nil {
 		writeError(err, w, r)
 		return
 	}
 
-	cr, partial, err := ranger.Range(rg)
+	// Requests for too many ranges are served in full (RFC 7233 section 6.1).
+	addVary(w.Header(), "Range")
+	if len(ranges) > maxRanges(r) {
+		writeResource(resource, w, r)
+		return
+	}
+	if len(ranges) > 1 {
+		writeRanges(resource, ranger, ranges, w, r)
+		return
+	}
+
+	cr, partial, err := ranger.Range(ranges[0])
 	if err != nil {
 		writeError(err, w, r)
 		return
 	}
 
-	addVary(w.Header(), "Range")
 	w.Header().Set("Content-Range", cr.String())
+
+	// Ranges in units other than bytes are pages of a collection.
+	if paginated, _ := r.Context().Value(paginateKey).(bool); paginated && cr.Range != nil && !strings.EqualFold(cr.Unit, "bytes") {
+		w.Header().Add("Link", (&Paginator{}).RangeLinks(r, cr).String())
+	}
 	writeResource(partial, w, r)
 }
 
+// writeRanges streams the parts of resource identified by ranges in a
+// multipart/byteranges response, each with its own Content-Type and
+// Content-Range headers. The parts are written as soon as they're encoded.
+func writeRanges(resource Resource, ranger Ranger, ranges RangeSet, w http.ResponseWriter, r *http.Request) {
+	boundary := multipart.NewWriter(ioutil.Discard).Boundary()
+
+	addVary(w.Header(), "Accept")
+	writeValidators(resource, w, r)
+	writeStream("multipart/byteranges; boundary="+boundary, func(sw io.Writer) error {
+		mw := multipart.NewWriter(sw)
+		if err := mw.SetBoundary(boundary); err != nil {
+			return err
+		}
+		for _, rg := range ranges {
+			cr, partial, err := ranger.Range(rg)
+			if err != nil {
+				return err
+			}
+			contentType, b, err := Marshal(partial, r)
+			if err != nil {
+				return err
+			}
+
+			header := make(textproto.MIMEHeader)
+			header.Set("Content-Type", contentType)
+			header.Set("Content-Range", cr.String())
+			part, err := mw.CreatePart(header)
+			if err != nil {
+				return err
+			}
+			if _, err := part.Write(b); err != nil {
+				return err
+			}
+		}
+		return mw.Close()
+	}, w, r)
+}
+
+// DefaultMaxRanges is the maximum number of ranges served in a single
+// multipart/byteranges response, unless the MaxRanges field of the mux is set.
+const DefaultMaxRanges = 16
+
+// maxRanges returns the maximum number of ranges served in response to r.
+func maxRanges(r *http.Request) int {
+	if route, _ := r.Context().Value(routeKey).(*Route); route != nil {
+		return route.mux.maxRanges()
+	}
+	return DefaultMaxRanges
+}
+
 /*
 Patcher is implemented by endpoints allowing the PATCH method.
 
@@ -266,18 +488,20 @@ Patcher is implemented by endpoints allowing the PATCH method.
 			return nil, rst.NotFound()
 		}
 
-		if r.Header.Get("Content-Type") != "application/www-form-urlencoded" {
-			return nil, rst.UnsupportedMediaType("application/www-form-urlencoded")
-		}
-
 		// Detect any writing conflicts
 		if rst.ValidateConditions(resource, r) {
 			return nil, rst.PreconditionFailed()
 		}
 
-		// Read r.Body, apply changes to resource, then return it
+		// Apply the JSON Merge Patch or JSON Patch in r.Body to resource
+		if err := rst.ApplyPatch(r, resource); err != nil {
+			return nil, err
+		}
 		return resource, nil
 	}
+
+OPTIONS requests on endpoints implementing Patcher list the formats supported
+by ApplyPatch in the Accept-Patch header.
 */
 type Patcher interface {
 	// Returns the patched resource or an error.
@@ -289,16 +513,19 @@ type PatchFunc func(RouteVars, *http.Request) (Resource, error)
 
 // ServeHTTP implements the http.Handler interface.
 func (f PatchFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	resource, err := f(getVars(r), r)
+	resource, err := f(Vars(r), r)
 	if err != nil {
 		writeError(err, w, r)
 		return
 	}
-	w.WriteHeader(http.StatusOK)
 	if resource == nil {
+		w.WriteHeader(http.StatusOK)
 		w.Write(noContent)
 		return
 	}
+	if writePreferredReturn(resource, http.StatusNoContent, w, r) {
+		return
+	}
 	writeResource(resource, w, r)
 }
 
@@ -319,6 +546,8 @@ Putter is implemented by endpoints allowing the PUT method.
 		// Read r.Body, apply changes to resource, then return it
 		return resource, nil
 	}
+
+Endpoints implementing Loader don't need to detect writing conflicts themselves.
 */
 type Putter interface {
 	// Returns the modified resource or an error.
@@ -330,16 +559,19 @@ type PutFunc func(RouteVars, *http.Request) (Resource, error)
 
 // ServeHTTP implements the http.Handler interface.
 func (f PutFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	resource, err := f(getVars(r), r)
+	resource, err := f(Vars(r), r)
 	if err != nil {
 		writeError(err, w, r)
 		return
 	}
-	w.WriteHeader(http.StatusOK)
 	if resource == nil {
+		w.WriteHeader(http.StatusOK)
 		w.Write(noContent)
 		return
 	}
+	if writePreferredReturn(resource, http.StatusNoContent, w, r) {
+		return
+	}
 	writeResource(resource, w, r)
 }
 
@@ -354,6 +586,12 @@ Poster is implemented by endpoints allowing the POST method.
 		uri := "https://example.com/resource/" + resource.ID
 		return resource, uri, nil
 	}
+
+A relative location, such as one returned by Mux.URL, is resolved against the
+URL of the request before being set in the Location header of the response.
+
+Long operations can return a Job instead. It's started in the background, and
+the response is a 202 Accepted pointing to its status. See Mux.HandleJobs.
 */
 type Poster interface {
 	// Returns the resource newly created and the URI where it can be located, or
@@ -366,15 +604,19 @@ type PostFunc func(RouteVars, *http.Request) (Resource, string, error)
 
 // ServeHTTP implements the http.Handler interface.
 func (f PostFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	resource, location, err := f(getVars(r), r)
+	resource, location, err := f(Vars(r), r)
 	if err != nil {
 		writeError(err, w, r)
 		return
 	}
 
+	if job, ok := resource.(*Job); ok {
+		writeJob(job, w, r)
+		return
+	}
+
 	if location != "" {
-		// TODO: make sure the URI is a fully qualified URL
-		w.Header().Set("Location", location)
+		w.Header().Set("Location", resolveLocation(r, location))
 	}
 
 	if resource == nil {
@@ -382,6 +624,9 @@ func (f PostFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.Write(noContent)
 		return
 	}
+	if writePreferredReturn(resource, http.StatusCreated, w, r) {
+		return
+	}
 	writeResource(resource, w, r)
 }
 
@@ -395,7 +640,7 @@ type DeleteFunc func(RouteVars, *http.Request) error
 
 // ServeHTTP implements the http.Handler interface.
 func (f DeleteFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	if err := f(getVars(r), r); err != nil {
+	if err := f(Vars(r), r); err != nil {
 		writeError(err, w, r)
 		return
 	}
@@ -403,6 +648,299 @@ func (f DeleteFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.Write(noContent)
 }
 
+/*
+Loader is implemented by endpoints that can load the current version of their
+resource. rst uses it to evaluate the conditional headers of PUT, PATCH and
+DELETE requests before calling the endpoint, and responds with 412 PRECONDITION
+FAILED when they don't match.
+
+	func (ep *endpoint) Load(vars rst.RouteVars, r *http.Request) (rst.Resource, error) {
+		return database.Find(vars.Get("id")), nil
+	}
+
+A nil resource means that it doesn't exist yet, which PUT requests can require
+with an If-None-Match: * header. Load is only called when the request contains
+conditional headers, or when they're required by the mux. Resources without an
+ETag are identified by the hash of their encoding when AutoETag is enabled.
+*/
+type Loader interface {
+	Load(RouteVars, *http.Request) (Resource, error)
+}
+
+// conditionalHeaders are the headers evaluated before PUT, PATCH and DELETE
+// requests.
+var conditionalHeaders = []string{"If-Match", "If-Unmodified-Since", "If-None-Match"}
+
+// checkPreconditions evaluates the conditional headers of unsafe requests
+// against the resource loaded from endpoint, if it implements Loader.
+func checkPreconditions(endpoint Endpoint, r *http.Request) error {
+	switch r.Method {
+	case Put, Patch, Delete:
+	default:
+		return nil
+	}
+
+	conditional := false
+	for _, key := range conditionalHeaders {
+		if r.Header.Get(key) != "" {
+			conditional = true
+			break
+		}
+	}
+	required, _ := r.Context().Value(conditionsKey).(bool)
+	loader, ok := endpoint.(Loader)
+	if !ok || (!conditional && !required) {
+		return nil
+	}
+	resource, err := loader.Load(Vars(r), r)
+	if err != nil {
+		return err
+	}
+	resource = currentVersion(resource, r)
+
+	// Conditions can only be required for resources that have validators.
+	if !conditional {
+		if resource != nil && (resource.ETag() != "" || !resource.LastModified().IsZero()) {
+			return PreconditionRequired()
+		}
+		return nil
+	}
+	if EvaluateConditions(resource, r) == http.StatusPreconditionFailed {
+		return PreconditionFailed()
+	}
+	return nil
+}
+
+// taggedResource overrides the entity tag of a resource.
+type taggedResource struct {
+	Resource
+	etag string
+}
+
+func (res *taggedResource) ETag() string {
+	return res.etag
+}
+
+// currentVersion returns resource, identified by the hash of its encoding if
+// it has no ETag and AutoETag is enabled, like in the responses serving it.
+func currentVersion(resource Resource, r *http.Request) Resource {
+	autoETag, _ := r.Context().Value(autoETagKey).(bool)
+	if !autoETag || resource == nil || parseETag(resource.ETag()).opaque != "" {
+		return resource
+	}
+	_, b, err := Marshal(resource, r)
+	if err != nil {
+		return resource
+	}
+	return &taggedResource{resource, hashETag(b).String()}
+}
+
+/*
+GetterContext is implemented by endpoints allowing the GET method, and needing
+the context of the request to be propagated to their backends.
+
+	func (ep *endpoint) GetContext(ctx context.Context, vars rst.RouteVars, r *http.Request) (rst.Resource, error) {
+		resource, err := database.FindContext(ctx, vars.Get("id"))
+		if err != nil {
+			return nil, err
+		}
+		if resource == nil {
+			return nil, rst.NotFound()
+		}
+		return resource, nil
+	}
+
+The context is canceled when the client's connection closes, and when the
+timeout of the route or of the mux expires. Endpoints implementing both
+GetterContext and Getter are served with GetContext.
+*/
+type GetterContext interface {
+	GetContext(context.Context, RouteVars, *http.Request) (Resource, error)
+}
+
+// GetContextFunc allows a GetterContext.GetContext method to be used an
+// http.Handler.
+type GetContextFunc func(context.Context, RouteVars, *http.Request) (Resource, error)
+
+// ServeHTTP implements the http.Handler interface.
+func (f GetContextFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	GetFunc(func(vars RouteVars, r *http.Request) (Resource, error) {
+		var resource Resource
+		err := callContext(r, func(ctx context.Context) (err error) {
+			resource, err = f(ctx, vars, r)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resource, nil
+	}).ServeHTTP(w, r)
+}
+
+// PatcherContext is like Patcher, but receives the context of the request.
+type PatcherContext interface {
+	PatchContext(context.Context, RouteVars, *http.Request) (Resource, error)
+}
+
+// PatchContextFunc allows a PatcherContext.PatchContext method to be used an
+// http.Handler.
+type PatchContextFunc func(context.Context, RouteVars, *http.Request) (Resource, error)
+
+// ServeHTTP implements the http.Handler interface.
+func (f PatchContextFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	PatchFunc(func(vars RouteVars, r *http.Request) (Resource, error) {
+		var resource Resource
+		err := callContext(r, func(ctx context.Context) (err error) {
+			resource, err = f(ctx, vars, r)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resource, nil
+	}).ServeHTTP(w, r)
+}
+
+// PutterContext is like Putter, but receives the context of the request.
+type PutterContext interface {
+	PutContext(context.Context, RouteVars, *http.Request) (Resource, error)
+}
+
+// PutContextFunc allows a PutterContext.PutContext method to be used an
+// http.Handler.
+type PutContextFunc func(context.Context, RouteVars, *http.Request) (Resource, error)
+
+// ServeHTTP implements the http.Handler interface.
+func (f PutContextFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	PutFunc(func(vars RouteVars, r *http.Request) (Resource, error) {
+		var resource Resource
+		err := callContext(r, func(ctx context.Context) (err error) {
+			resource, err = f(ctx, vars, r)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resource, nil
+	}).ServeHTTP(w, r)
+}
+
+// PosterContext is like Poster, but receives the context of the request.
+type PosterContext interface {
+	PostContext(context.Context, RouteVars, *http.Request) (resource Resource, location string, err error)
+}
+
+// PostContextFunc allows a PosterContext.PostContext method to be used an
+// http.Handler.
+type PostContextFunc func(context.Context, RouteVars, *http.Request) (Resource, string, error)
+
+// ServeHTTP implements the http.Handler interface.
+func (f PostContextFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	PostFunc(func(vars RouteVars, r *http.Request) (Resource, string, error) {
+		var resource Resource
+		var location string
+		err := callContext(r, func(ctx context.Context) (err error) {
+			resource, location, err = f(ctx, vars, r)
+			return err
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		return resource, location, nil
+	}).ServeHTTP(w, r)
+}
+
+// DeleterContext is like Deleter, but receives the context of the request.
+type DeleterContext interface {
+	DeleteContext(context.Context, RouteVars, *http.Request) error
+}
+
+// DeleteContextFunc allows a DeleterContext.DeleteContext method to be used an
+// http.Handler.
+type DeleteContextFunc func(context.Context, RouteVars, *http.Request) error
+
+// ServeHTTP implements the http.Handler interface.
+func (f DeleteContextFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	DeleteFunc(func(vars RouteVars, r *http.Request) error {
+		return callContext(r, func(ctx context.Context) error {
+			return f(ctx, vars, r)
+		})
+	}).ServeHTTP(w, r)
+}
+
+// callContext calls fn with the context of r, bounded by the timeout of the
+// route serving r if any.
+//
+// When a timeout is set, fn runs in its own goroutine. If the deadline expires
+// before it returns, a GatewayTimeout error is returned right away, and the
+// variables assigned by fn must not be read when the error is not nil. A
+// ServiceUnavailable error is returned if the request is canceled first.
+// Panics raised by fn once the error was returned are recovered, and logged by
+// the mux.
+func callContext(r *http.Request, fn func(ctx context.Context) error) error {
+	timeout, _ := r.Context().Value(timeoutKey).(time.Duration)
+	if timeout <= 0 {
+		return contextError(fn(r.Context()))
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	// abandoned is closed once callContext has returned, after which nothing
+	// is received from done and panicked anymore.
+	done := make(chan error)
+	panicked := make(chan interface{})
+	abandoned := make(chan struct{})
+	defer close(abandoned)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				select {
+				case panicked <- p:
+				case <-abandoned:
+					logPanic(r, p)
+				}
+			}
+		}()
+		err := fn(ctx)
+		select {
+		case done <- err:
+		case <-abandoned:
+		}
+	}()
+
+	select {
+	case err := <-done:
+		return contextError(err)
+	case p := <-panicked:
+		// Let the mux recover from it.
+		panic(p)
+	case <-ctx.Done():
+		return contextError(ctx.Err())
+	}
+}
+
+// logPanic logs p, a panic recovered while serving r, with the logger of the
+// mux serving it.
+func logPanic(r *http.Request, p interface{}) {
+	logger := defaultLogger
+	if route, _ := r.Context().Value(routeKey).(*Route); route != nil {
+		logger = route.mux.logger()
+	}
+	logger.Println(InternalServerError(fmt.Sprintf("%s", p), "", true).String())
+}
+
+// contextError converts the errors of package context into HTTP errors.
+func contextError(err error) error {
+	switch err {
+	case context.DeadlineExceeded:
+		return GatewayTimeout()
+	case context.Canceled:
+		return ServiceUnavailable()
+	}
+	return err
+}
+
 // OptionsHandler returns a handler that serves responses to OPTIONS requests
 // issued to the resource exposed by the given endpoint.
 func optionsHandler(endpoint Endpoint) http.Handler {
@@ -412,8 +950,14 @@ func optionsHandler(endpoint Endpoint) http.Handler {
 			return
 		}
 
-		w.Header().Set("Allow", strings.Join(AllowedMethods(endpoint), ", "))
-		w.Header().Set("Content-Type", strings.Join(alternatives, ";"))
+		allowed := AllowedMethods(endpoint)
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		w.Header().Set("Content-Type", strings.Join(getCodecs(r).Encodable(), ";"))
+		for _, method := range allowed {
+			if method == Patch {
+				w.Header().Set("Accept-Patch", strings.Join(patchTypes, ", "))
+			}
+		}
 		w.WriteHeader(http.StatusNoContent)
 	})
 }
@@ -436,6 +980,9 @@ func (h *endpointHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		} else {
 			methodHandler = NotFound()
 		}
+	} else if err := checkPreconditions(h.endpoint, r); err != nil {
+		writeError(err, w, r)
+		return
 	}
 	methodHandler.ServeHTTP(w, r)
 }
@@ -443,26 +990,53 @@ func (h *endpointHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 // getMethodHandler returns the handler in endpoint for the given of HTTP
 // request method and header
 func getMethodHandler(endpoint Endpoint, method string, header http.Header) http.Handler {
-	switch strings.ToUpper(method) {
-	case Options:
+	method = strings.ToUpper(method)
+	if method == Options {
 		return optionsHandler(endpoint)
+	}
+	if router, ok := endpoint.(methodRouter); ok {
+		return router.methodHandler(method, header)
+	}
+
+	switch method {
 	case Head, Get:
+		if i, supported := endpoint.(EventSource); supported && acceptsEvents(header) {
+			return EventSourceFunc(i.Events)
+		}
+		if i, supported := endpoint.(GetterContext); supported {
+			return GetContextFunc(i.GetContext)
+		}
 		if i, supported := endpoint.(Getter); supported {
 			return GetFunc(i.Get)
 		}
+		if i, supported := endpoint.(EventSource); supported {
+			return EventSourceFunc(i.Events)
+		}
 	case Patch:
+		if i, supported := endpoint.(PatcherContext); supported {
+			return PatchContextFunc(i.PatchContext)
+		}
 		if i, supported := endpoint.(Patcher); supported {
 			return PatchFunc(i.Patch)
 		}
 	case Put:
+		if i, supported := endpoint.(PutterContext); supported {
+			return PutContextFunc(i.PutContext)
+		}
 		if i, supported := endpoint.(Putter); supported {
 			return PutFunc(i.Put)
 		}
 	case Post:
+		if i, supported := endpoint.(PosterContext); supported {
+			return PostContextFunc(i.PostContext)
+		}
 		if i, supported := endpoint.(Poster); supported {
 			return PostFunc(i.Post)
 		}
 	case Delete:
+		if i, supported := endpoint.(DeleterContext); supported {
+			return DeleteContextFunc(i.DeleteContext)
+		}
 		if i, supported := endpoint.(Deleter); supported {
 			return DeleteFunc(i.Delete)
 		}
@@ -472,6 +1046,12 @@ func getMethodHandler(endpoint Endpoint, method string, header http.Header) http
 
 var supportedMethods = []string{Head, Get, Patch, Put, Post, Delete}
 
+// methodRouter is implemented by endpoints that provide the handler of each
+// HTTP method they support.
+type methodRouter interface {
+	methodHandler(method string, header http.Header) http.Handler
+}
+
 // methodLister is implements by endpoints that need to control the list of
 // HTTP methods they support.
 type methodLister interface {
@@ -2,9 +2,15 @@ package rst
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
@@ -18,6 +24,12 @@ var (
 	tPatchFunc      = reflect.TypeOf(new(PatchFunc)).Elem()
 	tPutFunc        = reflect.TypeOf(new(PutFunc)).Elem()
 	tDeleteFunc     = reflect.TypeOf(new(DeleteFunc)).Elem()
+
+	tGetContextFunc    = reflect.TypeOf(new(GetContextFunc)).Elem()
+	tPostContextFunc   = reflect.TypeOf(new(PostContextFunc)).Elem()
+	tPatchContextFunc  = reflect.TypeOf(new(PatchContextFunc)).Elem()
+	tPutContextFunc    = reflect.TypeOf(new(PutContextFunc)).Elem()
+	tDeleteContextFunc = reflect.TypeOf(new(DeleteContextFunc)).Elem()
 )
 
 type allInterfaces struct{}
@@ -38,6 +50,26 @@ func (a *allInterfaces) Delete(vars RouteVars, r *http.Request) error {
 	panic("not implemented")
 }
 
+type allContextInterfaces struct {
+	allInterfaces
+}
+
+func (a *allContextInterfaces) GetContext(ctx context.Context, vars RouteVars, r *http.Request) (Resource, error) {
+	panic("not implemented")
+}
+func (a *allContextInterfaces) PostContext(ctx context.Context, vars RouteVars, r *http.Request) (Resource, string, error) {
+	panic("not implemented")
+}
+func (a *allContextInterfaces) PatchContext(ctx context.Context, vars RouteVars, r *http.Request) (Resource, error) {
+	panic("not implemented")
+}
+func (a *allContextInterfaces) PutContext(ctx context.Context, vars RouteVars, r *http.Request) (Resource, error) {
+	panic("not implemented")
+}
+func (a *allContextInterfaces) DeleteContext(ctx context.Context, vars RouteVars, r *http.Request) error {
+	panic("not implemented")
+}
+
 func TestValidateConditions(t *testing.T) {
 	resource := testPeople[0]
 	var test = func(d time.Time, etag string, expected bool) {
@@ -54,13 +86,59 @@ func TestValidateConditions(t *testing.T) {
 		}
 	}
 
-	test(time.Time{}, "", false)                                           // nil, nil
-	test(time.Time{}, resource.ETag(), false)                              // nil, false
-	test(time.Time{}, "blabla", true)                                      // nil, true
-	test(resource.LastModified(), "", false)                               // false, nil
-	test(resource.LastModified().Add(24*time.Hour), "", false)             // false, nil
-	test(resource.LastModified().Add(-24*time.Hour), "", true)             // true, nil
-	test(resource.LastModified().Add(-4*time.Hour), resource.ETag(), true) // true, false
+	test(time.Time{}, "", false)                                            // nil, nil
+	test(time.Time{}, resource.ETag(), false)                               // nil, false
+	test(time.Time{}, "blabla", true)                                       // nil, true
+	test(resource.LastModified(), "", false)                                // false, nil
+	test(resource.LastModified().Add(24*time.Hour), "", false)              // false, nil
+	test(resource.LastModified().Add(-24*time.Hour), "", true)              // true, nil
+	test(resource.LastModified().Add(-4*time.Hour), resource.ETag(), false) // If-Match takes precedence
+}
+
+func TestEvaluateConditions(t *testing.T) {
+	resource := testPeople[0]
+	etag := resource.ETag()
+	lastModified := resource.LastModified().UTC().Format(rfc1123)
+	before := resource.LastModified().Add(-time.Hour).UTC().Format(rfc1123)
+
+	var test = func(method string, res Resource, header map[string]string, expected int) {
+		r, _ := http.NewRequest(method, "http://www.example.com", nil)
+		for k, v := range header {
+			r.Header.Set(k, v)
+		}
+		if got := EvaluateConditions(res, r); got != expected {
+			t.Error(method, header, "Got:", got, "Wanted:", expected)
+		}
+	}
+
+	test(Get, resource, nil, 0)
+
+	// If-Match uses the strong comparison.
+	test(Put, resource, map[string]string{"If-Match": `"` + etag + `"`}, 0)
+	test(Put, resource, map[string]string{"If-Match": `"a", "` + etag + `"`}, 0)
+	test(Put, resource, map[string]string{"If-Match": `W/"` + etag + `"`}, http.StatusPreconditionFailed)
+	test(Put, resource, map[string]string{"If-Match": "*"}, 0)
+	test(Put, nil, map[string]string{"If-Match": "*"}, http.StatusPreconditionFailed)
+
+	// If-Unmodified-Since is ignored when If-Match is present.
+	test(Put, resource, map[string]string{"If-Unmodified-Since": lastModified}, 0)
+	test(Put, resource, map[string]string{"If-Unmodified-Since": before}, http.StatusPreconditionFailed)
+	test(Put, resource, map[string]string{"If-Unmodified-Since": before, "If-Match": etag}, 0)
+
+	// If-None-Match uses the weak comparison.
+	test(Get, resource, map[string]string{"If-None-Match": `"a", W/"` + etag + `"`}, http.StatusNotModified)
+	test(Head, resource, map[string]string{"If-None-Match": "*"}, http.StatusNotModified)
+	test("get", resource, map[string]string{"If-None-Match": etag}, http.StatusNotModified)
+	test(Get, resource, map[string]string{"If-None-Match": `"a"`}, 0)
+	test(Delete, resource, map[string]string{"If-None-Match": etag}, http.StatusPreconditionFailed)
+	test(Put, nil, map[string]string{"If-None-Match": "*"}, 0)
+
+	// If-Modified-Since is ignored when If-None-Match is present, and for
+	// unsafe methods.
+	test(Get, resource, map[string]string{"If-Modified-Since": lastModified}, http.StatusNotModified)
+	test(Get, resource, map[string]string{"If-Modified-Since": before}, 0)
+	test(Get, resource, map[string]string{"If-Modified-Since": lastModified, "If-None-Match": `"a"`}, 0)
+	test(Put, resource, map[string]string{"If-Modified-Since": lastModified}, 0)
 }
 
 func TestAllowedMethods(t *testing.T) {
@@ -113,6 +191,43 @@ func TestGetMethodHandler(t *testing.T) {
 	test(Delete, nil, tDeleteFunc)
 }
 
+func TestGetMethodHandlerContext(t *testing.T) {
+	var test = func(method string, expected reflect.Type) {
+		h := getMethodHandler(&allContextInterfaces{}, method, nil)
+		if ht := reflect.TypeOf(h); ht != expected {
+			t.Errorf("handler for %s returned %s when %s was expected", method, ht, expected)
+		}
+	}
+	test(Head, tGetContextFunc)
+	test(Get, tGetContextFunc)
+	test(Patch, tPatchContextFunc)
+	test(Put, tPutContextFunc)
+	test(Post, tPostContextFunc)
+	test(Delete, tDeleteContextFunc)
+}
+
+func TestGetContextHandler(t *testing.T) {
+	type key string
+	mux := NewMux()
+	mux.GetContext("/people/{id}", func(ctx context.Context, vars RouteVars, r *http.Request) (Resource, error) {
+		if ctx.Value(key("user")) != "john" {
+			t.Fatal("context of the request was not propagated")
+		}
+		return testPeople[0], nil
+	})
+	mux.Use(func(route *Route, vars RouteVars, w http.ResponseWriter, r *http.Request, next http.Handler) error {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), key("user"), "john")))
+		return nil
+	})
+
+	r, _ := http.NewRequest(Get, "http://www.example.com/people/1", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatal("Got:", w.Code, "Wanted:", http.StatusOK)
+	}
+}
+
 func TestMethodNotAllowed(t *testing.T) {
 	rr := newRequestResponse(Delete, testServerAddr+"/people", nil, nil)
 	if err := rr.TestStatusCode(http.StatusMethodNotAllowed); err != nil {
@@ -249,6 +364,84 @@ func TestPartialGetHandler(t *testing.T) {
 	test(Get)
 }
 
+func TestSuffixRangeGetHandler(t *testing.T) {
+	header := make(http.Header)
+	header.Set("Accept", "application/json")
+	header.Set("Range", "resources=-5")
+	rr := newRequestResponse(Get, testServerAddr+"/people", header, nil)
+
+	if err := rr.TestStatusCode(http.StatusPartialContent); err != nil {
+		t.Fatal(err)
+	}
+	total := len(testPeopleResourceCollection)
+	if err := rr.TestHeader("Content-Range", fmt.Sprintf("resources %d-%d/%d", total-5, total-1, total)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMultiRangeGetHandler(t *testing.T) {
+	header := make(http.Header)
+	header.Set("Accept", "application/json")
+	header.Set("Range", "resources=10-14,0-4,3-6")
+	rr := newRequestResponse(Get, testServerAddr+"/people", header, nil)
+
+	if err := rr.TestStatusCode(http.StatusPartialContent); err != nil {
+		t.Fatal(err)
+	}
+	if err := rr.TestHasNoHeader("Content-Range"); err != nil {
+		t.Fatal(err)
+	}
+	mediaType, params, err := mime.ParseMediaType(rr.resp.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/byteranges" {
+		t.Fatal("Got:", rr.resp.Header.Get("Content-Type"), "Wanted: multipart/byteranges")
+	}
+
+	total := len(testPeopleResourceCollection)
+	expected := []string{
+		fmt.Sprintf("resources 0-6/%d", total),
+		fmt.Sprintf("resources 10-14/%d", total),
+	}
+	reader := multipart.NewReader(rr.resp.Body, params["boundary"])
+	for i := 0; ; i++ {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			if i != len(expected) {
+				t.Fatal("Got:", i, "parts. Wanted:", len(expected))
+			}
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i >= len(expected) {
+			t.Fatal("unexpected part:", part.Header)
+		}
+		if got := part.Header.Get("Content-Range"); got != expected[i] {
+			t.Fatal("Got:", got, "Wanted:", expected[i])
+		}
+		if got := part.Header.Get("Content-Type"); got != "application/json; charset=utf-8" {
+			t.Fatal("Got:", got, "Wanted: application/json; charset=utf-8")
+		}
+		var people []*person
+		if err := json.NewDecoder(part).Decode(&people); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Requests for too many ranges are served in full.
+	mux := NewMux()
+	mux.MaxRanges = 1
+	mux.Handle("/people", EndpointHandler(&peopleCollection{}))
+	r, _ := http.NewRequest(Get, "http://www.example.com/people", nil)
+	r.Header.Set("Accept", "application/json")
+	r.Header.Set("Range", "resources=10-14,0-4")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/json; charset=utf-8" {
+		t.Fatal("Got:", w.Code, w.Header(), "Wanted:", http.StatusOK)
+	}
+}
+
 func TestIfRangeGetHander(t *testing.T) {
 	var test = func(ifRange string, expected int) {
 		header := make(http.Header)
@@ -279,7 +472,7 @@ func TestPartialGetNotSatisfiableHandler(t *testing.T) {
 		if err := rr.TestStatusCode(http.StatusRequestedRangeNotSatisfiable); err != nil {
 			t.Fatal(err)
 		}
-		if err := rr.TestHeader("Content-Range", fmt.Sprintf("*/%d", len(testPeopleResourceCollection))); err != nil {
+		if err := rr.TestHeader("Content-Range", fmt.Sprintf("resources */%d", len(testPeopleResourceCollection))); err != nil {
 			t.Fatal(err)
 		}
 	}
@@ -352,3 +545,397 @@ func TestPost(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestPostRelativeLocation(t *testing.T) {
+	mux := NewMux()
+	mux.Post("/people", func(vars RouteVars, r *http.Request) (Resource, string, error) {
+		return nil, "/people/42", nil
+	})
+
+	r, _ := http.NewRequest(Post, "http://www.example.com/people", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	if expected := "http://www.example.com/people/42"; w.Header().Get("Location") != expected {
+		t.Fatal("Got:", w.Header().Get("Location"), "Wanted:", expected)
+	}
+}
+
+func TestContextTimeout(t *testing.T) {
+	mux := NewMux()
+	mux.Debug = true
+	mux.Timeout = 20 * time.Millisecond
+	mux.GetContext("/slow", func(ctx context.Context, vars RouteVars, r *http.Request) (Resource, error) {
+		time.Sleep(time.Second)
+		return testPeople[0], nil
+	})
+	mux.GetContext("/waiting", func(ctx context.Context, vars RouteVars, r *http.Request) (Resource, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}).Timeout(10 * time.Millisecond)
+	mux.GetContext("/fast", func(ctx context.Context, vars RouteVars, r *http.Request) (Resource, error) {
+		if _, ok := ctx.Deadline(); !ok {
+			t.Fatal("context is expected to have a deadline")
+		}
+		return testPeople[0], nil
+	})
+	mux.DeleteContext("/canceled", func(ctx context.Context, vars RouteVars, r *http.Request) error {
+		return context.Canceled
+	})
+	mux.PostContext("/panic", func(ctx context.Context, vars RouteVars, r *http.Request) (Resource, string, error) {
+		panic("provoked panic")
+	})
+	mux.Group("/v2").Get("/plain", func(vars RouteVars, r *http.Request) (Resource, error) {
+		time.Sleep(40 * time.Millisecond)
+		return testPeople[0], nil
+	})
+	mux.GetContext("/unlimited", func(ctx context.Context, vars RouteVars, r *http.Request) (Resource, error) {
+		if _, ok := ctx.Deadline(); ok {
+			t.Fatal("context is not expected to have a deadline")
+		}
+		return testPeople[0], nil
+	}).Timeout(0)
+
+	// Late panics are logged with the logger of the group of the route.
+	logs := make(logWriter, 1)
+	late := mux.Group("")
+	late.Logger = log.New(logs, "", 0)
+	late.GetContext("/late-panic", func(ctx context.Context, vars RouteVars, r *http.Request) (Resource, error) {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		panic("late panic")
+	})
+
+	var test = func(method, path string, code int) {
+		r, _ := http.NewRequest(method, "http://www.example.com"+path, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		if w.Code != code {
+			t.Fatal(path, "Got:", w.Code, "Wanted:", code)
+		}
+	}
+	test(Get, "/slow", http.StatusGatewayTimeout)
+	test(Get, "/waiting", http.StatusGatewayTimeout)
+	test(Get, "/fast", http.StatusOK)
+	test(Delete, "/canceled", http.StatusServiceUnavailable)
+	test(Post, "/panic", http.StatusInternalServerError)
+	test(Get, "/v2/plain", http.StatusOK) // not context-aware
+	test(Get, "/unlimited", http.StatusOK)
+
+	// Panics raised after the timeout are logged.
+	test(Get, "/late-panic", http.StatusGatewayTimeout)
+	select {
+	case entry := <-logs:
+		if !strings.Contains(entry, "late panic") {
+			t.Fatal("Got:", entry, "Wanted: a logged panic")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("late panic was not logged")
+	}
+}
+
+// logWriter sends the entries written by a logger in a channel.
+type logWriter chan string
+
+func (w logWriter) Write(b []byte) (int, error) {
+	w <- string(b)
+	return len(b), nil
+}
+
+type loaderEndpoint struct {
+	loads int
+}
+
+func (ep *loaderEndpoint) Load(vars RouteVars, r *http.Request) (Resource, error) {
+	ep.loads++
+	if vars.Get("id") == "new" {
+		return nil, nil
+	}
+	if vars.Get("id") != testPeople[0].ID {
+		return nil, NotFound()
+	}
+	return testPeople[0], nil
+}
+
+func (ep *loaderEndpoint) Put(vars RouteVars, r *http.Request) (Resource, error) {
+	return nil, nil
+}
+
+func (ep *loaderEndpoint) Delete(vars RouteVars, r *http.Request) error {
+	return nil
+}
+
+func TestLoaderPreconditions(t *testing.T) {
+	endpoint := &loaderEndpoint{}
+	mux := NewMux()
+	mux.HandleEndpoint("/people/{id}", endpoint)
+	strict := mux.Group("/strict")
+	strict.RequireConditions = true
+	strict.HandleEndpoint("/people/{id}", endpoint)
+	strict.Get("/people", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return testPeople[0], nil
+	})
+
+	var test = func(method, path string, header map[string]string, code int) {
+		r, _ := http.NewRequest(method, "http://www.example.com"+path, nil)
+		for k, v := range header {
+			r.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		if w.Code != code {
+			t.Fatal(method, path, header, "Got:", w.Code, "Wanted:", code)
+		}
+	}
+
+	id := testPeople[0].ID
+	test(Put, "/people/"+id, nil, http.StatusOK)
+	if endpoint.loads != 0 {
+		t.Fatal("resource is not expected to be loaded without conditional headers")
+	}
+	test(Put, "/people/"+id, map[string]string{"If-Match": `"` + testPeople[0].ETag() + `"`}, http.StatusOK)
+	test(Put, "/people/"+id, map[string]string{"If-Match": `"outdated"`}, http.StatusPreconditionFailed)
+	test(Delete, "/people/"+id, map[string]string{"If-Unmodified-Since": testPeople[0].LastModified().Add(-time.Hour).UTC().Format(rfc1123)}, http.StatusPreconditionFailed)
+	test(Put, "/people/new", map[string]string{"If-None-Match": "*"}, http.StatusOK)
+	test(Put, "/people/"+id, map[string]string{"If-None-Match": "*"}, http.StatusPreconditionFailed)
+	test(Delete, "/people/unknown", map[string]string{"If-Match": "*"}, http.StatusNotFound)
+
+	test(Put, "/strict/people/"+id, nil, http.StatusPreconditionRequired)
+	test(Delete, "/strict/people/"+id, map[string]string{"If-Match": "*"}, http.StatusNoContent)
+	test(Get, "/strict/people", nil, http.StatusOK)
+
+	// Conditions are only required for resources with validators.
+	test(Put, "/strict/people/new", nil, http.StatusOK)
+	strict.Put("/notes", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return nil, nil
+	})
+	test(Put, "/strict/notes", nil, http.StatusOK)
+}
+
+type untaggedLoaderEndpoint struct{}
+
+func (ep *untaggedLoaderEndpoint) Load(vars RouteVars, r *http.Request) (Resource, error) {
+	return NewEnvelope(map[string]string{"name": "John"}, time.Time{}, "", 0), nil
+}
+
+func (ep *untaggedLoaderEndpoint) Get(vars RouteVars, r *http.Request) (Resource, error) {
+	return ep.Load(vars, r)
+}
+
+func (ep *untaggedLoaderEndpoint) Put(vars RouteVars, r *http.Request) (Resource, error) {
+	return ep.Load(vars, r)
+}
+
+func TestAutoETagPreconditions(t *testing.T) {
+	mux := NewMux()
+	mux.AutoETag = true
+	mux.RequireConditions = true
+	mux.HandleEndpoint("/people/1", &untaggedLoaderEndpoint{})
+
+	var test = func(method, ifMatch string, code int) *httptest.ResponseRecorder {
+		r, _ := http.NewRequest(method, "http://www.example.com/people/1", nil)
+		r.Header.Set("Accept", "application/json")
+		if ifMatch != "" {
+			r.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		if w.Code != code {
+			t.Fatal(method, ifMatch, "Got:", w.Code, "Wanted:", code)
+		}
+		return w
+	}
+	etag := test(Get, "", http.StatusOK).Header().Get("ETag")
+	test(Put, "", http.StatusPreconditionRequired)
+	test(Put, etag, http.StatusOK)
+	test(Put, `"outdated"`, http.StatusPreconditionFailed)
+}
+
+func TestUnsafeMethodCacheControl(t *testing.T) {
+	mux := NewMux()
+	resource := NewEnvelope("ttl", testTimeReference, "ttl", time.Minute)
+	mux.Get("/ttl", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return resource, nil
+	})
+	mux.Put("/ttl", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return resource, nil
+	})
+	for method, expected := range map[string]string{Get: "max-age=60", Put: ""} {
+		r, _ := http.NewRequest(method, "http://www.example.com/ttl", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		if got := w.Header().Get("Cache-Control"); got != expected {
+			t.Fatal(method, "Got:", got, "Wanted:", expected)
+		}
+	}
+}
+
+func TestAutoETag(t *testing.T) {
+	mux := NewMux()
+	mux.AutoETag = true
+	mux.Get("/anonymous", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return NewEnvelope(strings.Repeat("a", CompressionThreshold), testTimeReference, "", time.Hour), nil
+	})
+	mux.Get("/weak", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return NewEnvelope("weak", testTimeReference, WeakETag("v1"), time.Hour), nil
+	})
+
+	var test = func(path string, header map[string]string, code int) *httptest.ResponseRecorder {
+		r, _ := http.NewRequest(Get, "http://www.example.com"+path, nil)
+		r.Header.Set("Accept", "application/json")
+		for k, v := range header {
+			r.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		if w.Code != code {
+			t.Fatal(path, header, "Got:", w.Code, "Wanted:", code)
+		}
+		return w
+	}
+
+	etag := test("/anonymous", nil, http.StatusOK).Header().Get("ETag")
+	if !strings.HasPrefix(etag, `"`) || len(etag) < 3 {
+		t.Fatal("expected a strong ETag. Got:", etag)
+	}
+	test("/anonymous", map[string]string{"If-None-Match": etag}, http.StatusNotModified)
+
+	weak := test("/anonymous", map[string]string{"Accept-Encoding": "gzip"}, http.StatusOK).Header().Get("ETag")
+	if weak != "W/"+etag {
+		t.Fatal("Got:", weak, "Wanted:", "W/"+etag)
+	}
+	test("/anonymous", map[string]string{"Accept-Encoding": "gzip", "If-None-Match": weak}, http.StatusNotModified)
+
+	if got := test("/weak", nil, http.StatusOK).Header().Get("ETag"); got != `W/"v1"` {
+		t.Fatal("Got:", got, "Wanted:", `W/"v1"`)
+	}
+	test("/weak", map[string]string{"If-None-Match": `"v1"`}, http.StatusNotModified)
+}
+
+func TestCacheController(t *testing.T) {
+	mux := NewMux()
+	mux.Get("/ttl", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return NewEnvelope("ttl", testTimeReference, "ttl", time.Minute), nil
+	})
+	mux.Get("/private", func(vars RouteVars, r *http.Request) (Resource, error) {
+		envelope := NewEnvelope("private", testTimeReference, "private", time.Minute)
+		envelope.SetCacheControl(&CacheControl{Private: true, NoCache: true})
+		return envelope, nil
+	})
+	overridden := mux.Group("/overridden")
+	overridden.Header().Set("Cache-Control", "no-store")
+	overridden.Get("/ttl", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return NewEnvelope("ttl", testTimeReference, "ttl", time.Minute), nil
+	})
+
+	var test = func(path, expected string) {
+		r, _ := http.NewRequest(Get, "http://www.example.com"+path, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		if got := w.Header().Get("Cache-Control"); got != expected {
+			t.Fatal(path, "Got:", got, "Wanted:", expected)
+		}
+	}
+	test("/ttl", "max-age=60")
+	test("/private", "private, no-cache")
+	test("/overridden/ttl", "no-store")
+}
+
+func TestPreferReturn(t *testing.T) {
+	mux := NewMux()
+	resource := NewEnvelope(map[string]string{"name": "John"}, testTimeReference, "john", 0)
+	mux.Post("/people", func(vars RouteVars, r *http.Request) (Resource, string, error) {
+		return resource, "/people/1", nil
+	})
+	mux.Put("/people/1", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return resource, nil
+	})
+	mux.Patch("/people/1", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return resource, nil
+	})
+
+	var test = func(method, path, prefer string, code int, body bool) *httptest.ResponseRecorder {
+		r, _ := http.NewRequest(method, "http://www.example.com"+path, nil)
+		r.Header.Set("Accept", "application/json")
+		if prefer != "" {
+			r.Header.Set("Prefer", prefer)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		if w.Code != code || (w.Body.Len() > 0) != body {
+			t.Fatal(method, prefer, "Got:", w.Code, w.Body.String(), "Wanted:", code)
+		}
+		return w
+	}
+
+	w := test(Post, "/people", "return=minimal", http.StatusCreated, false)
+	if w.Header().Get("Location") != "http://www.example.com/people/1" || w.Header().Get("ETag") != `"john"` {
+		t.Fatal("Got:", w.Header(), "Wanted: Location and ETag headers")
+	}
+	if w.Header().Get("Preference-Applied") != "return=minimal" || !strings.Contains(strings.Join(w.Header()["Vary"], ","), "Prefer") {
+		t.Fatal("Got:", w.Header(), "Wanted: Preference-Applied and Vary headers")
+	}
+	test(Put, "/people/1", "return=minimal", http.StatusNoContent, false)
+	w = test(Patch, "/people/1", "return=minimal", http.StatusNoContent, false)
+	if w.Header().Get("ETag") != `"john"` {
+		t.Fatal("Got:", w.Header(), "Wanted: an ETag header")
+	}
+
+	w = test(Patch, "/people/1", "return=representation", http.StatusOK, true)
+	if w.Header().Get("Preference-Applied") != "return=representation" {
+		t.Fatal("Got:", w.Header(), "Wanted: a Preference-Applied header")
+	}
+	if w = test(Put, "/people/1", "", http.StatusOK, true); w.Header().Get("Preference-Applied") != "" {
+		t.Fatal("Got:", w.Header(), "Wanted: no Preference-Applied header")
+	}
+	for _, method := range []string{Put, Patch} {
+		// Headers set after the status code are not sent.
+		header := test(method, "/people/1", "", http.StatusOK, true).Result().Header
+		if header.Get("Content-Type") == "" || header.Get("ETag") != `"john"` || header.Get("Last-Modified") == "" {
+			t.Fatal(method, "Got:", header, "Wanted: the headers of the representation")
+		}
+	}
+}
+
+func TestPreferHandling(t *testing.T) {
+	header := make(http.Header)
+	header.Set("Accept", "image/png")
+	rr := newRequestResponse(Get, testServerAddr+"/people", header, nil)
+	if err := rr.TestStatusCode(http.StatusNotAcceptable); err != nil {
+		t.Fatal(err)
+	}
+	header.Set("Prefer", "handling=lenient")
+	rr = newRequestResponse(Get, testServerAddr+"/people", header, nil)
+	if err := rr.TestStatusCode(http.StatusOK); err != nil {
+		t.Fatal(err)
+	}
+	if err := rr.TestHeader("Preference-Applied", "handling=lenient"); err != nil {
+		t.Fatal(err)
+	}
+	// The preference is not applied when the Accept header is satisfied.
+	header.Set("Accept", "application/json")
+	rr = newRequestResponse(Get, testServerAddr+"/people", header, nil)
+	if err := rr.TestHasNoHeader("Preference-Applied"); err != nil {
+		t.Fatal(err)
+	}
+
+	header = make(http.Header)
+	header.Set("Accept", "application/json")
+	header.Set("Range", "lines=0-9")
+	rr = newRequestResponse(Get, testServerAddr+"/people", header, nil)
+	if err := rr.TestStatusCode(http.StatusOK); err != nil {
+		t.Fatal(err)
+	}
+	header.Set("Prefer", "handling=strict")
+	rr = newRequestResponse(Get, testServerAddr+"/people", header, nil)
+	if err := rr.TestStatusCode(http.StatusBadRequest); err != nil {
+		t.Fatal(err)
+	}
+	if err := rr.TestHeader("Preference-Applied", "handling=strict"); err != nil {
+		t.Fatal(err)
+	}
+	header.Del("Range")
+	rr = newRequestResponse(Get, testServerAddr+"/people", header, nil)
+	if err := rr.TestHasNoHeader("Preference-Applied"); err != nil {
+		t.Fatal(err)
+	}
+}
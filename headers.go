@@ -4,6 +4,8 @@
 package rst
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math"
@@ -12,6 +14,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // addVary adds value to the list of values of the "Vary" header if it's not
@@ -112,7 +115,9 @@ func ParseAccept(header string) Accept {
 }
 
 // Negotiate the most appropriate contentType given the accept header clauses
-// and a list of alternatives.
+// and a list of alternatives. Nothing matches when no alternative is given:
+// use Codecs.Negotiate to pick among the media types of a codec registry, such
+// as DefaultCodecs.
 func (accept Accept) Negotiate(alternatives ...string) (contentType string) {
 	asp := make([][]string, 0, len(alternatives))
 	for _, ctype := range alternatives {
@@ -138,7 +143,8 @@ func (accept Accept) Negotiate(alternatives ...string) (contentType string) {
 }
 
 var (
-	rangeRe = regexp.MustCompile("^(\\w+)=(\\d+)-(\\d+)?$")
+	rangeUnitRe = regexp.MustCompile("^\\w+$")
+	rangeSpecRe = regexp.MustCompile("^(\\d*)-(\\d*)$")
 )
 
 // Range is a structured representation of the Range request header.
@@ -147,6 +153,11 @@ type Range struct {
 	Unit string
 	From uint64
 	To   uint64
+
+	// Suffix is true for ranges requesting the last To units of a resource,
+	// such as "bytes=-500". Ranges are always resolved before they're passed to
+	// Ranger.Range, and Suffix is then false.
+	Suffix bool
 }
 
 // Len returns the number of units requested in this range.
@@ -175,48 +186,149 @@ implementer.
 func (r *Range) adjust(ranger Ranger) error {
 
 	count := ranger.Count()
-	if r.From > count {
-		return RequestedRangeNotSatisfiable(&ContentRange{Total: count})
+	if r.Suffix {
+		// A suffix longer than the resource selects all of it.
+		if r.To == 0 || count == 0 {
+			return RequestedRangeNotSatisfiable(&ContentRange{&Range{Unit: r.Unit}, count})
+		}
+		if r.To < count {
+			r.From = count - r.To
+		}
+		r.To, r.Suffix = count-1, false
+		return nil
+	}
+
+	if r.From >= count {
+		return RequestedRangeNotSatisfiable(&ContentRange{&Range{Unit: r.Unit}, count})
+	}
+	if r.To > count-1 {
+		r.To = count - 1
 	}
-	r.To = uint64(math.Min(float64(r.To), float64(count-1)))
 	return nil
 }
 
+// RangeSet is the list of ranges of the same unit requested in a Range header.
+type RangeSet []*Range
+
+func (rs RangeSet) Len() int {
+	return len(rs)
+}
+
+func (rs RangeSet) Less(i, j int) bool {
+	return rs[i].From < rs[j].From
+}
+
+func (rs RangeSet) Swap(i, j int) {
+	rs[i], rs[j] = rs[j], rs[i]
+}
+
+// Unit returns the unit of the ranges in rs.
+func (rs RangeSet) Unit() string {
+	if len(rs) == 0 {
+		return ""
+	}
+	return rs[0].Unit
+}
+
+// validate the ranges in rs for ranger.
+func (rs RangeSet) validate(ranger Ranger) error {
+	if len(rs) == 0 {
+		return errors.New("empty range set")
+	}
+	return rs[0].validate(ranger)
+}
+
 /*
-ParseRange parses raw into a new Range instance.
+adjust returns the ranges of rs falling within the boundaries of ranger, sorted
+and with overlapping or adjacent ranges coalesced. Ranges that do not overlap
+the current extent of ranger are dropped, and a RequestedRangeNotSatifiable
+error is returned if none is left.
+*/
+func (rs RangeSet) adjust(ranger Ranger) (RangeSet, error) {
+	var adjusted RangeSet
+	for _, r := range rs {
+		r := *r
+		if err := r.adjust(ranger); err == nil {
+			adjusted = append(adjusted, &r)
+		}
+	}
+	if len(adjusted) == 0 {
+		return nil, RequestedRangeNotSatisfiable(&ContentRange{&Range{Unit: rs.Unit()}, ranger.Count()})
+	}
+
+	sort.Sort(adjusted)
+	coalesced := adjusted[:1]
+	for _, r := range adjusted[1:] {
+		last := coalesced[len(coalesced)-1]
+		if r.From <= last.To+1 {
+			if r.To > last.To {
+				last.To = r.To
+			}
+			continue
+		}
+		coalesced = append(coalesced, r)
+	}
+	return coalesced, nil
+}
 
-	ParseRange("bytes=0-1024") 	// (OK)
-	ParseRange("resources=239-392")	// (OK)
-	ParseRange("items=39-")		// (OK)
-	ParseRange("bytes 50-100")	// (ERROR: syntax)
-	ParseRange("bytes=100-50")	// (ERROR: logic)
+/*
+ParseRange parses raw into a new RangeSet, listing ranges in the order in which
+they were requested.
+
+	ParseRange("bytes=0-1024") 		// (OK)
+	ParseRange("resources=239-392")		// (OK)
+	ParseRange("items=39-")			// (OK)
+	ParseRange("bytes=-500")		// (OK: last 500 bytes)
+	ParseRange("bytes=0-99, 200-299")	// (OK)
+	ParseRange("bytes 50-100")		// (ERROR: syntax)
+	ParseRange("bytes=-")			// (ERROR: syntax)
+	ParseRange("bytes=100-50")		// (ERROR: logic)
 */
-func ParseRange(raw string) (*Range, error) {
-	m := rangeRe.FindStringSubmatch(raw)
-	if m == nil || len(m) < 4 {
+func ParseRange(raw string) (RangeSet, error) {
+	i := strings.Index(raw, "=")
+	if i < 0 || !rangeUnitRe.MatchString(raw[:i]) {
 		return nil, errors.New("malformed Range header value")
 	}
+	unit := raw[:i]
 
-	r := &Range{
-		Unit: m[1],
-	}
+	var rs RangeSet
+	for _, spec := range strings.Split(raw[i+1:], ",") {
+		m := rangeSpecRe.FindStringSubmatch(strings.TrimSpace(spec))
+		if m == nil || m[1] == "" && m[2] == "" {
+			return nil, errors.New("malformed Range header value")
+		}
 
-	// Regex guarantees numbers are valid, so errors of strconv.ParseUint can
-	// be safely ignored.
+		r := &Range{
+			Unit: unit,
+		}
 
-	r.From, _ = strconv.ParseUint(m[2], 10, 64)
+		// Regex guarantees numbers are valid, so errors of strconv.ParseUint can
+		// be safely ignored.
 
-	// To is optional. When omitted, it means "all remaining available units".
-	if m[3] != "" {
-		r.To, _ = strconv.ParseUint(m[3], 10, 64)
-		if r.From > r.To {
-			return nil, errors.New("invalid Range header value")
+		// From is omitted in suffix ranges, where To is the number of units
+		// requested.
+		if m[1] == "" {
+			r.To, _ = strconv.ParseUint(m[2], 10, 64)
+			r.Suffix = true
+			rs = append(rs, r)
+			continue
+		}
+
+		r.From, _ = strconv.ParseUint(m[1], 10, 64)
+
+		// To is optional. When omitted, it means "all remaining available units".
+		if m[2] != "" {
+			r.To, _ = strconv.ParseUint(m[2], 10, 64)
+			if r.From > r.To {
+				return nil, errors.New("invalid Range header value")
+			}
+		} else {
+			r.To = math.MaxUint64
 		}
-	} else {
-		r.To = math.MaxUint64
+		rs = append(rs, r)
 	}
 
-	return r, nil
+	return rs, nil
 }
 
 // ContentRange is a structured representation of the Content-Range response
@@ -237,3 +349,250 @@ func (cr *ContentRange) String() string {
 
 	return fmt.Sprintf("%s %d-%d/%d", cr.Unit, cr.From, cr.To, cr.Total)
 }
+
+// CacheControl is a structured representation of the Cache-Control response
+// header. Zero durations are omitted.
+type CacheControl struct {
+	Public               bool          // public
+	Private              bool          // private
+	NoCache              bool          // no-cache
+	NoStore              bool          // no-store
+	NoTransform          bool          // no-transform
+	MustRevalidate       bool          // must-revalidate
+	ProxyRevalidate      bool          // proxy-revalidate
+	Immutable            bool          // immutable
+	MaxAge               time.Duration // max-age
+	SharedMaxAge         time.Duration // s-maxage
+	StaleWhileRevalidate time.Duration // stale-while-revalidate
+	StaleIfError         time.Duration // stale-if-error
+}
+
+func (cc *CacheControl) String() string {
+	var directives []string
+	var flag = func(set bool, directive string) {
+		if set {
+			directives = append(directives, directive)
+		}
+	}
+	var duration = func(d time.Duration, directive string) {
+		if d > 0 {
+			directives = append(directives, fmt.Sprintf("%s=%d", directive, int64(d/time.Second)))
+		}
+	}
+
+	flag(cc.Public, "public")
+	flag(cc.Private, "private")
+	flag(cc.NoCache, "no-cache")
+	flag(cc.NoStore, "no-store")
+	flag(cc.NoTransform, "no-transform")
+	flag(cc.MustRevalidate, "must-revalidate")
+	flag(cc.ProxyRevalidate, "proxy-revalidate")
+	flag(cc.Immutable, "immutable")
+	duration(cc.MaxAge, "max-age")
+	duration(cc.SharedMaxAge, "s-maxage")
+	duration(cc.StaleWhileRevalidate, "stale-while-revalidate")
+	duration(cc.StaleIfError, "stale-if-error")
+	return strings.Join(directives, ", ")
+}
+
+// ParseCacheControl parses the raw value of a Cache-Control header. Unknown
+// directives are ignored.
+func ParseCacheControl(raw string) *CacheControl {
+	cc := &CacheControl{}
+	for _, directive := range strings.Split(raw, ",") {
+		name, value := strings.TrimSpace(directive), ""
+		if i := strings.Index(name, "="); i >= 0 {
+			name, value = strings.TrimSpace(name[:i]), strings.Trim(strings.TrimSpace(name[i+1:]), `"`)
+		}
+		seconds, _ := strconv.ParseInt(value, 10, 64)
+		d := time.Duration(seconds) * time.Second
+
+		switch strings.ToLower(name) {
+		case "public":
+			cc.Public = true
+		case "private":
+			cc.Private = true
+		case "no-cache":
+			cc.NoCache = true
+		case "no-store":
+			cc.NoStore = true
+		case "no-transform":
+			cc.NoTransform = true
+		case "must-revalidate":
+			cc.MustRevalidate = true
+		case "proxy-revalidate":
+			cc.ProxyRevalidate = true
+		case "immutable":
+			cc.Immutable = true
+		case "max-age":
+			cc.MaxAge = d
+		case "s-maxage":
+			cc.SharedMaxAge = d
+		case "stale-while-revalidate":
+			cc.StaleWhileRevalidate = d
+		case "stale-if-error":
+			cc.StaleIfError = d
+		}
+	}
+	return cc
+}
+
+// Preferences represents the preferences found in the Prefer header of a
+// request, as defined in RFC 7240.
+type Preferences struct {
+	Return       string            // return: "minimal" or "representation".
+	Handling     string            // handling: "strict" or "lenient".
+	Wait         time.Duration     // wait
+	RespondAsync bool              // respond-async
+	Extensions   map[string]string // Other preferences, by lower-cased name.
+}
+
+// ParsePrefer parses the raw value of a Prefer header. Only the first
+// occurrence of a preference is considered, and the parameters of preferences
+// as well as the invalid values of the preferences of RFC 7240 are ignored.
+func ParsePrefer(raw string) *Preferences {
+	prefs := &Preferences{Extensions: make(map[string]string)}
+	seen := make(map[string]bool)
+	for _, preference := range strings.Split(raw, ",") {
+		if i := strings.Index(preference, ";"); i >= 0 {
+			preference = preference[:i]
+		}
+		name, value := strings.TrimSpace(preference), ""
+		if i := strings.Index(name, "="); i >= 0 {
+			name, value = strings.TrimSpace(name[:i]), strings.Trim(strings.TrimSpace(name[i+1:]), `"`)
+		}
+		name = strings.ToLower(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		switch name {
+		case "return":
+			if value = strings.ToLower(value); value == "minimal" || value == "representation" {
+				prefs.Return = value
+			}
+		case "handling":
+			if value = strings.ToLower(value); value == "strict" || value == "lenient" {
+				prefs.Handling = value
+			}
+		case "wait":
+			if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds > 0 {
+				prefs.Wait = time.Duration(seconds) * time.Second
+			}
+		case "respond-async":
+			prefs.RespondAsync = true
+		default:
+			prefs.Extensions[name] = value
+		}
+	}
+	return prefs
+}
+
+// RequestPreferences returns the preferences found in the Prefer headers of r.
+func RequestPreferences(r *http.Request) *Preferences {
+	return ParsePrefer(strings.Join(r.Header["Prefer"], ","))
+}
+
+// addPreferenceApplied adds preference to the list of values of the
+// "Preference-Applied" header, and Prefer to the Vary header.
+func addPreferenceApplied(header http.Header, preference string) {
+	addVary(header, "Prefer")
+	for _, v := range header["Preference-Applied"] {
+		if v == preference {
+			return
+		}
+	}
+	header.Add("Preference-Applied", preference)
+}
+
+// entityTag is an entity tag, as defined in RFC 7232, section 2.3.
+type entityTag struct {
+	weak   bool
+	opaque string
+}
+
+// parseETag parses a single entity tag. Tags missing their double quotes are
+// tolerated.
+func parseETag(raw string) entityTag {
+	raw = strings.TrimSpace(raw)
+	var tag entityTag
+	if strings.HasPrefix(raw, "W/") {
+		tag.weak = true
+		raw = raw[2:]
+	}
+	if len(raw) >= 2 && raw[0] == '"' && raw[len(raw)-1] == '"' {
+		raw = raw[1 : len(raw)-1]
+	}
+	tag.opaque = raw
+	return tag
+}
+
+// parseETags parses the comma-separated list of entity tags found in the
+// If-Match and If-None-Match headers. any is true if the list is "*".
+func parseETags(raw string) (tags []entityTag, any bool) {
+	if strings.TrimSpace(raw) == "*" {
+		return nil, true
+	}
+	quoted, start := false, 0
+	for i := 0; i <= len(raw); i++ {
+		if i < len(raw) && raw[i] == '"' {
+			quoted = !quoted
+		}
+		if i == len(raw) || (raw[i] == ',' && !quoted) {
+			if tag := parseETag(raw[start:i]); tag.opaque != "" {
+				tags = append(tags, tag)
+			}
+			start = i + 1
+		}
+	}
+	return tags, false
+}
+
+// String returns the quoted form of t, as written in an ETag header.
+func (t entityTag) String() string {
+	if t.weak {
+		return `W/"` + t.opaque + `"`
+	}
+	return `"` + t.opaque + `"`
+}
+
+// WeakETag marks tag as a weak entity tag. Resources can return it from ETag
+// when their representations are equivalent, but not byte-for-byte identical.
+//
+//	func (p *Person) ETag() string {
+//		return rst.WeakETag(p.Version)
+//	}
+func WeakETag(tag string) string {
+	t := parseETag(tag)
+	t.weak = true
+	return t.String()
+}
+
+// hashETag returns a strong entity tag identifying b.
+func hashETag(b []byte) entityTag {
+	sum := sha256.Sum256(b)
+	return entityTag{opaque: hex.EncodeToString(sum[:16])}
+}
+
+// strongMatch returns true if t and other are both strong and identical.
+func (t entityTag) strongMatch(other entityTag) bool {
+	return !t.weak && !other.weak && t.opaque == other.opaque
+}
+
+// weakMatch returns true if t and other are identical, regardless of their
+// weakness.
+func (t entityTag) weakMatch(other entityTag) bool {
+	return t.opaque == other.opaque
+}
+
+// matches returns true if t matches one of tags, using the weak comparison if
+// weak is true, and the strong comparison otherwise.
+func (t entityTag) matches(tags []entityTag, weak bool) bool {
+	for _, tag := range tags {
+		if (weak && t.weakMatch(tag)) || t.strongMatch(tag) {
+			return true
+		}
+	}
+	return false
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestAddVary(t *testing.T) {
@@ -35,11 +37,17 @@ func TestAddVary(t *testing.T) {
 
 func TestParseRange(t *testing.T) {
 	var test = func(raw, unit string, from, to uint64) {
-		parsed, err := ParseRange(raw)
+		rs, err := ParseRange(raw)
 		if err != nil {
 			t.Errorf("%s: %s", raw, err)
 			return
 		}
+		if len(rs) != 1 {
+			t.Errorf("%s: expected 1 range. Got %d", raw, len(rs))
+			return
+		}
+
+		parsed := rs[0]
 
 		if parsed.Unit != unit {
 			t.Errorf("%s: expected Unit %s. Got %s", raw, unit, parsed.Unit)
@@ -63,11 +71,79 @@ func TestParseRange(t *testing.T) {
 	if _, err := ParseRange("bytes=12-10"); err == nil {
 		t.Errorf("Error not cached")
 	}
+
+	if _, err := ParseRange("bytes=0-10,,20-30"); err == nil {
+		t.Errorf("Error not cached")
+	}
+
+	if _, err := ParseRange("bytes=-"); err == nil {
+		t.Errorf("Error not cached")
+	}
+
+	if rs, err := ParseRange("bytes=-500"); err != nil || !rs[0].Suffix || rs[0].To != 500 {
+		t.Errorf("bytes=-500: unexpected suffix range %v (%v)", rs, err)
+	}
+
+	rs, err := ParseRange("bytes=0-99, 200-299,50-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := RangeSet{
+		{Unit: "bytes", From: 0, To: 99},
+		{Unit: "bytes", From: 200, To: 299},
+		{Unit: "bytes", From: 50, To: math.MaxUint64},
+	}
+	if !reflect.DeepEqual(rs, expected) {
+		t.Fatal("Got:", rs, "Wanted:", expected)
+	}
+}
+
+func TestRangeSetAdjust(t *testing.T) {
+	var test = func(raw string, expected ...[2]uint64) {
+		rs, _ := ParseRange(raw)
+		adjusted, err := rs.adjust(testPeopleResourceCollection)
+		if err != nil {
+			t.Fatal(raw, err)
+		}
+		if len(adjusted) != len(expected) {
+			t.Fatal(raw, "Got:", len(adjusted), "ranges. Wanted:", len(expected))
+		}
+		for i, rg := range adjusted {
+			if rg.From != expected[i][0] || rg.To != expected[i][1] {
+				t.Fatal(raw, "Got:", rg.From, rg.To, "Wanted:", expected[i])
+			}
+		}
+	}
+	count := uint64(len(testPeopleResourceCollection))
+	test("resources=0-4,10-14", [2]uint64{0, 4}, [2]uint64{10, 14})
+	test("resources=10-14,0-4", [2]uint64{0, 4}, [2]uint64{10, 14})
+	test("resources=0-4,3-9,10-12", [2]uint64{0, 12})
+	test("resources=0-4,100000-", [2]uint64{0, 4})
+	test("resources=5-", [2]uint64{5, count - 1})
+	test("resources=-3", [2]uint64{count - 3, count - 1})
+	test("resources=-100000", [2]uint64{0, count - 1})
+	test("resources=0-1,-2", [2]uint64{0, 1}, [2]uint64{count - 2, count - 1})
+
+	var unsatisfiable = func(ranger Ranger, raw, contentRange string) {
+		rs, _ := ParseRange(raw)
+		_, err := rs.adjust(ranger)
+		if err == nil {
+			t.Fatal(raw, "unsatisfiable ranges were accepted")
+		}
+		if got := err.(*Error).Header.Get("Content-Range"); got != contentRange {
+			t.Fatal(raw, "Got:", got, "Wanted:", contentRange)
+		}
+	}
+	unsatisfiable(testPeopleResourceCollection, "resources=100000-100001,200000-", fmt.Sprintf("resources */%d", count))
+	unsatisfiable(testPeopleResourceCollection, fmt.Sprintf("resources=%d-", count), fmt.Sprintf("resources */%d", count))
+	unsatisfiable(testPeopleResourceCollection, "resources=-0", fmt.Sprintf("resources */%d", count))
+	unsatisfiable(resourceCollection{}, "resources=0-", "resources */0")
+	unsatisfiable(resourceCollection{}, "resources=-10", "resources */0")
 }
 
 func TestAcceptAdjust(t *testing.T) {
 	from, to := uint64(15), uint64(100000)
-	rg := &Range{"resources", from, to}
+	rg := &Range{Unit: "resources", From: from, To: to}
 	rg.adjust(testPeopleResourceCollection)
 
 	if from != rg.From {
@@ -112,4 +188,83 @@ func TestAcceptNegociate(t *testing.T) {
 	test([]string{"text/html", "text/plain", "text/n3"}, "text/html")
 	test([]string{"text/n3", "text/plain"}, "text/plain")
 	test([]string{"text/n3", "application/rdf+xml"}, "text/n3")
+	test(nil, "")
+}
+
+func TestParseETags(t *testing.T) {
+	var test = func(raw string, expected []entityTag, expectedAny bool) {
+		tags, any := parseETags(raw)
+		if any != expectedAny || !reflect.DeepEqual(tags, expected) {
+			t.Fatal(raw, "Got:", tags, any, "Wanted:", expected, expectedAny)
+		}
+	}
+	test("", nil, false)
+	test("*", nil, true)
+	test(`"a"`, []entityTag{{false, "a"}}, false)
+	test(`W/"a", "b,c" ,d`, []entityTag{{true, "a"}, {false, "b,c"}, {false, "d"}}, false)
+}
+
+func TestWeakETag(t *testing.T) {
+	var test = func(tag, expected string) {
+		if got := WeakETag(tag); got != expected {
+			t.Fatal(tag, "Got:", got, "Wanted:", expected)
+		}
+	}
+	test("v1", `W/"v1"`)
+	test(`"v1"`, `W/"v1"`)
+	test(`W/"v1"`, `W/"v1"`)
+}
+
+func TestCacheControl(t *testing.T) {
+	var test = func(cc *CacheControl, expected string) {
+		if got := cc.String(); got != expected {
+			t.Fatal("Got:", got, "Wanted:", expected)
+		}
+	}
+	test(&CacheControl{}, "")
+	test(&CacheControl{MaxAge: 90 * time.Second}, "max-age=90")
+	test(&CacheControl{NoStore: true}, "no-store")
+	test(&CacheControl{
+		Public:               true,
+		Immutable:            true,
+		MaxAge:               time.Hour,
+		SharedMaxAge:         time.Minute,
+		StaleWhileRevalidate: 30 * time.Second,
+		StaleIfError:         24 * time.Hour,
+	}, "public, immutable, max-age=3600, s-maxage=60, stale-while-revalidate=30, stale-if-error=86400")
+}
+
+func TestParseCacheControl(t *testing.T) {
+	var test = func(raw string, expected *CacheControl) {
+		if got := ParseCacheControl(raw); *got != *expected {
+			t.Fatal(raw, "Got:", got, "Wanted:", expected)
+		}
+	}
+	test("", &CacheControl{})
+	test("no-store", &CacheControl{NoStore: true})
+	test("Private, max-age=60", &CacheControl{Private: true, MaxAge: time.Minute})
+	test(`public, s-maxage="30", unknown=1, immutable`, &CacheControl{Public: true, SharedMaxAge: 30 * time.Second, Immutable: true})
+}
+
+func TestParsePrefer(t *testing.T) {
+	var test = func(raw string, expected *Preferences) {
+		got := ParsePrefer(raw)
+		if got.Return != expected.Return || got.Handling != expected.Handling || got.Wait != expected.Wait || got.RespondAsync != expected.RespondAsync {
+			t.Fatal(raw, "Got:", got, "Wanted:", expected)
+		}
+		if len(got.Extensions) != len(expected.Extensions) {
+			t.Fatal(raw, "Got:", got.Extensions, "Wanted:", expected.Extensions)
+		}
+		for name, value := range expected.Extensions {
+			if got.Extensions[name] != value {
+				t.Fatal(raw, "Got:", got.Extensions, "Wanted:", expected.Extensions)
+			}
+		}
+	}
+	test("", &Preferences{})
+	test("return=minimal", &Preferences{Return: "minimal"})
+	test(`Return="Representation", wait=10, respond-async`, &Preferences{Return: "representation", Wait: 10 * time.Second, RespondAsync: true})
+	test("handling=lenient; foo=bar, handling=strict, return=minimal", &Preferences{Handling: "lenient", Return: "minimal"})
+	test("return=everything, handling=loose, wait=-1, wait=5", &Preferences{})
+	test("priority=5, Foo", &Preferences{Extensions: map[string]string{"priority": "5", "foo": ""}})
 }
@@ -0,0 +1,271 @@
+package rst
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxIdempotencyKeyLength is the maximum length of the value of the
+// Idempotency-Key header of a request.
+const MaxIdempotencyKeyLength = 255
+
+// IdempotentResponse is the response to a request made with an
+// Idempotency-Key, as stored in an IdempotencyStore.
+type IdempotentResponse struct {
+	Fingerprint string // Digest of the payload of the request.
+	Code        int
+	Header      http.Header
+	Body        []byte // Encoded, but not compressed.
+}
+
+// IdempotencyStore stores the responses to the requests made with an
+// Idempotency-Key. Implementations must be safe for concurrent use.
+type IdempotencyStore interface {
+	// Reserve reserves key for the request about to be processed, and returns
+	// true. If key is already reserved, it returns the response stored for it
+	// and false, or a nil response and false while the request that reserved
+	// it is still in flight.
+	//
+	// Reservations must expire after a lease, so that a key isn't locked
+	// forever by a request that neither saved nor released it.
+	Reserve(key string) (*IdempotentResponse, bool, error)
+
+	// Save stores the response to the request that reserved key.
+	Save(key string, response *IdempotentResponse) error
+
+	// Release cancels the reservation of key when no response was saved, so
+	// that the request can be retried.
+	Release(key string) error
+}
+
+/*
+Idempotent returns a middleware making the POST and PATCH requests sent with an
+Idempotency-Key header safe to retry. The first response to each key and
+endpoint is saved in store, and replayed with an Idempotent-Replayed header in
+response to the requests retried with the same key:
+
+	store := rst.NewMemoryIdempotencyStore(24*time.Hour, 5*time.Minute)
+	mux.Post("/payments", createPayment).Use(rst.Idempotent(store))
+
+A 409 Conflict error is returned while a request with the same key is still in
+flight, and a 422 Unprocessable Entity error if a key is reused with a different
+payload. Server errors are not saved, and requests without an Idempotency-Key
+header are processed normally.
+
+Responses are saved uncompressed, and compressed again according to the
+Accept-Encoding header of each retry. Retries that don't accept the media type
+of the saved response fail with 406 Not Acceptable.
+
+store defaults to an in-memory store keeping responses for 24 hours, with
+reservations of 5 minutes, if nil.
+*/
+func Idempotent(store IdempotencyStore) Middleware {
+	if store == nil {
+		store = NewMemoryIdempotencyStore(24*time.Hour, 5*time.Minute)
+	}
+	return func(route *Route, vars RouteVars, w http.ResponseWriter, r *http.Request, next http.Handler) error {
+		idempotencyKey := r.Header.Get("Idempotency-Key")
+		if idempotencyKey == "" || (r.Method != Post && r.Method != Patch) {
+			next.ServeHTTP(w, r)
+			return nil
+		}
+		if len(idempotencyKey) > MaxIdempotencyKeyLength {
+			return BadRequest("Invalid Idempotency-Key", "The Idempotency-Key header is too long.")
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return err
+		}
+		r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		fingerprint := requestFingerprint(r, body)
+
+		key := r.Method + " " + r.Host + r.URL.Path + " " + idempotencyKey
+		stored, reserved, err := store.Reserve(key)
+		if err != nil {
+			return err
+		}
+		if !reserved {
+			switch {
+			case stored == nil:
+				err := Conflict()
+				err.Description = "A request with the same Idempotency-Key is still being processed."
+				err.Header.Set("Retry-After", "1")
+				return err
+			case stored.Fingerprint != fingerprint:
+				err := UnprocessableEntity()
+				err.Description = "The Idempotency-Key was already used with a different payload."
+				return err
+			}
+			if !acceptsStored(stored, r) {
+				return NotAcceptable()
+			}
+			header := make(http.Header)
+			for k, v := range stored.Header {
+				header[k] = v
+			}
+			header.Set("Idempotent-Replayed", "true")
+			replayUncompressed(w, stored.Code, header, stored.Body, r)
+			return nil
+		}
+
+		saved := false
+		defer func() {
+			if !saved {
+				store.Release(key)
+			}
+		}()
+
+		// Record the uncompressed response, with the headers set so far. Only
+		// the headers set by next are saved.
+		initial := w.Header()
+		rec := &responseRecorder{header: make(http.Header), code: http.StatusOK}
+		for k, v := range initial {
+			rec.header[k] = append([]string(nil), v...)
+		}
+		uncompressed := r.WithContext(r.Context())
+		uncompressed.Header = make(http.Header)
+		for k, v := range r.Header {
+			uncompressed.Header[k] = v
+		}
+		uncompressed.Header.Del("Accept-Encoding")
+		rw := newResponseWriter(rec)
+		next.ServeHTTP(rw, uncompressed)
+		rw.close()
+
+		if rec.code < 500 {
+			response := &IdempotentResponse{
+				Fingerprint: fingerprint,
+				Code:        rec.code,
+				Header:      make(http.Header),
+				Body:        rec.body.Bytes(),
+			}
+			for k, v := range rec.header {
+				if strings.Join(v, ",") != strings.Join(initial[k], ",") {
+					response.Header[k] = v
+				}
+			}
+			saved = store.Save(key, response) == nil
+		}
+		replayUncompressed(w, rec.code, rec.header, rec.body.Bytes(), r)
+		return nil
+	}
+}
+
+// acceptsStored returns true if the Accept header of r, a retry, accepts the
+// media type of the stored response to the original request. Errors are
+// always replayed.
+func acceptsStored(stored *IdempotentResponse, r *http.Request) bool {
+	accept := ParseAccept(r.Header.Get("Accept"))
+	contentType := stored.Header.Get("Content-Type")
+	if len(accept) == 0 || contentType == "" || stored.Code >= 400 {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return true
+	}
+	return accept.Negotiate(mediaType) != ""
+}
+
+// replayUncompressed writes a recorded response in w, compressed according to
+// the Accept-Encoding header of r.
+func replayUncompressed(w http.ResponseWriter, code int, header http.Header, body []byte, r *http.Request) {
+	rw, ok := w.(*responseWriter)
+	if !ok || header.Get("Content-Encoding") != "" {
+		replay(w, code, header, body)
+		return
+	}
+	for k, v := range header {
+		w.Header()[k] = v
+	}
+	if compression := getCompressionFormat(body, r); compression != "" {
+		w.Header().Set("Content-Encoding", compression)
+		addVary(w.Header(), "Accept-Encoding")
+	}
+	rw.WriteHeader(code)
+	rw.Write(body)
+}
+
+// requestFingerprint returns a digest of the payload of r.
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Header.Get("Content-Type")))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// memoryIdempotencyStore is an IdempotencyStore keeping responses in memory.
+type memoryIdempotencyStore struct {
+	ttl       time.Duration
+	lease     time.Duration
+	mu        sync.Mutex
+	entries   map[string]*idempotencyEntry
+	lastSweep time.Time
+}
+
+// idempotencyEntry is a key reserved in a memoryIdempotencyStore. response is
+// nil while the request is in flight, until its lease expires.
+type idempotencyEntry struct {
+	response *IdempotentResponse
+	expires  time.Time
+}
+
+// NewMemoryIdempotencyStore returns an IdempotencyStore keeping the responses
+// in memory for the duration of ttl. Keys are reserved for requests in flight
+// for the duration of lease at most.
+func NewMemoryIdempotencyStore(ttl, lease time.Duration) IdempotencyStore {
+	return &memoryIdempotencyStore{
+		ttl:       ttl,
+		lease:     lease,
+		entries:   make(map[string]*idempotencyEntry),
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *memoryIdempotencyStore) Reserve(key string) (*IdempotentResponse, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if entry, ok := s.entries[key]; ok && entry.expires.After(now) {
+		return entry.response, false, nil
+	}
+	s.entries[key] = &idempotencyEntry{expires: now.Add(s.lease)}
+	return nil, true, nil
+}
+
+func (s *memoryIdempotencyStore) Save(key string, response *IdempotentResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.entries[key] = &idempotencyEntry{response: response, expires: now.Add(s.ttl)}
+
+	// Expired responses and reservations are removed at most once per ttl.
+	if now.Sub(s.lastSweep) >= s.ttl {
+		for k, entry := range s.entries {
+			if !entry.expires.After(now) {
+				delete(s.entries, k)
+			}
+		}
+		s.lastSweep = now
+	}
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if entry, ok := s.entries[key]; ok && entry.response == nil {
+		delete(s.entries, key)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package rst
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIdempotent(t *testing.T) {
+	var (
+		calls   int32
+		block   = make(chan struct{})
+		started = make(chan struct{}, 1)
+	)
+	mux := NewMux()
+	mux.Post("/people", func(vars RouteVars, r *http.Request) (Resource, string, error) {
+		n := atomic.AddInt32(&calls, 1)
+		if r.Header.Get("X-Block") != "" {
+			started <- struct{}{}
+			<-block
+		}
+		if r.Header.Get("X-Fail") != "" {
+			return nil, "", InternalServerError("", "", false)
+		}
+		id := strconv.Itoa(int(n))
+		if r.Header.Get("X-Large") != "" {
+			return NewEnvelope(map[string]string{"id": id, "bio": strings.Repeat("a", CompressionThreshold)}, testTimeReference, "", 0), "/people/" + id, nil
+		}
+		return NewEnvelope(map[string]string{"id": id}, testTimeReference, "", 0), "/people/" + id, nil
+	}).Use(Idempotent(nil))
+
+	var post = func(key, body string, header http.Header) *httptest.ResponseRecorder {
+		r, _ := http.NewRequest(Post, "http://www.example.com/people", strings.NewReader(body))
+		r.Header.Set("Accept", "application/json")
+		r.Header.Set("Content-Type", "application/json")
+		for k, v := range header {
+			r.Header[k] = v
+		}
+		if key != "" {
+			r.Header.Set("Idempotency-Key", key)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w
+	}
+
+	first := post("key-1", `{"name":"John"}`, nil)
+	if first.Code != http.StatusCreated || first.Header().Get("Location") != "http://www.example.com/people/1" {
+		t.Fatal("Got:", first.Code, first.Header(), "Wanted:", http.StatusCreated)
+	}
+	retry := post("key-1", `{"name":"John"}`, nil)
+	if retry.Code != http.StatusCreated || retry.Header().Get("Location") != "http://www.example.com/people/1" || retry.Body.String() != first.Body.String() {
+		t.Fatal("Got:", retry.Code, retry.Header(), retry.Body.String(), "Wanted:", first.Body.String())
+	}
+	if retry.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatal("replayed response has no Idempotent-Replayed header")
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Fatal("Got:", n, "calls", "Wanted:", 1)
+	}
+
+	// Reuse with a different payload.
+	if w := post("key-1", `{"name":"Jane"}`, nil); w.Code != http.StatusUnprocessableEntity {
+		t.Fatal("Got:", w.Code, "Wanted:", http.StatusUnprocessableEntity)
+	}
+
+	// No key, or a new key.
+	if w := post("", `{"name":"John"}`, nil); w.Code != http.StatusCreated || w.Header().Get("Location") != "http://www.example.com/people/2" {
+		t.Fatal("Got:", w.Code, w.Header())
+	}
+	if w := post("key-2", `{"name":"John"}`, nil); w.Code != http.StatusCreated || w.Header().Get("Location") != "http://www.example.com/people/3" {
+		t.Fatal("Got:", w.Code, w.Header())
+	}
+
+	// Server errors are not saved.
+	fail := http.Header{"X-Fail": {"1"}}
+	if w := post("key-3", `{}`, fail); w.Code != http.StatusInternalServerError {
+		t.Fatal("Got:", w.Code, "Wanted:", http.StatusInternalServerError)
+	}
+	if w := post("key-3", `{}`, nil); w.Code != http.StatusCreated {
+		t.Fatal("Got:", w.Code, "Wanted:", http.StatusCreated)
+	}
+
+	// Concurrent requests with the same key.
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- post("key-4", `{}`, http.Header{"X-Block": {"1"}})
+	}()
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("request not started")
+	}
+	if w := post("key-4", `{}`, nil); w.Code != http.StatusConflict {
+		t.Fatal("Got:", w.Code, "Wanted:", http.StatusConflict)
+	}
+	close(block)
+	if w := <-done; w.Code != http.StatusCreated {
+		t.Fatal("Got:", w.Code, "Wanted:", http.StatusCreated)
+	}
+	if w := post("key-4", `{}`, nil); w.Code != http.StatusCreated || w.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatal("Got:", w.Code, w.Header())
+	}
+
+	// Responses are compressed for each retry, and replayed only to retries
+	// accepting their media type.
+	large := post("key-5", `{}`, http.Header{"X-Large": {"1"}})
+	if large.Code != http.StatusCreated || large.Header().Get("Content-Encoding") != "" {
+		t.Fatal("Got:", large.Code, large.Header())
+	}
+	w := post("key-5", `{}`, http.Header{"Accept-Encoding": {"gzip"}})
+	if w.Code != http.StatusCreated || w.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatal("Got:", w.Code, w.Header(), "Wanted: a compressed replay")
+	}
+	reader, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := ioutil.ReadAll(reader); string(b) != large.Body.String() {
+		t.Fatal("Got:", string(b), "Wanted:", large.Body.String())
+	}
+	if w := post("key-5", `{}`, http.Header{"Accept": {"application/xml"}}); w.Code != http.StatusNotAcceptable {
+		t.Fatal("Got:", w.Code, "Wanted:", http.StatusNotAcceptable)
+	}
+
+	if w := post(strings.Repeat("k", MaxIdempotencyKeyLength+1), `{}`, nil); w.Code != http.StatusBadRequest {
+		t.Fatal("Got:", w.Code, "Wanted:", http.StatusBadRequest)
+	}
+}
+
+func TestMemoryIdempotencyStore(t *testing.T) {
+	store := NewMemoryIdempotencyStore(50*time.Millisecond, 20*time.Millisecond)
+	if _, reserved, _ := store.Reserve("a"); !reserved {
+		t.Fatal("key not reserved")
+	}
+	if response, reserved, _ := store.Reserve("a"); reserved || response != nil {
+		t.Fatal("key reserved twice")
+	}
+	store.Release("a")
+	if _, reserved, _ := store.Reserve("a"); !reserved {
+		t.Fatal("released key not reserved")
+	}
+
+	store.Save("a", &IdempotentResponse{Code: http.StatusCreated})
+	if response, reserved, _ := store.Reserve("a"); reserved || response == nil || response.Code != http.StatusCreated {
+		t.Fatal("Got:", response, reserved, "Wanted: the saved response")
+	}
+	store.Release("a")
+	if response, _, _ := store.Reserve("a"); response == nil {
+		t.Fatal("saved response released")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, reserved, _ := store.Reserve("a"); !reserved {
+		t.Fatal("expired key not reserved")
+	}
+
+	// Reservations expire after their lease.
+	time.Sleep(30 * time.Millisecond)
+	if _, reserved, _ := store.Reserve("a"); !reserved {
+		t.Fatal("expired reservation not renewed")
+	}
+}
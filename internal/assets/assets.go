@@ -122,86 +122,87 @@ var _esc_data = map[string]*_esc_file{
 
 	"/internal/assets/error.html": {
 		local: "internal/assets/error.html",
-		size:  42463,
-		compressed: "\x1f\x8b\b\x00\x00\tn\x88\x00\xff\xe4]ݓ۸\x91\u007f\u07ff\x82\x99M.\xeb\xb5ġ\xbeG\x92=\x95\xdc&W٪l.\x95\xf8\x1e\xae6~\xa0HHbL\x91\nI\xcdث\xd2\xff~\xf8F㋤4㼜\x9d\xac%\xf4\x0f\x8dFw\x03݄\x00\xf0ݯ\xfe\xf0\xdf?|\xf8\u07ff\xfe1\xd87\x87\xfc\xf1\x9b" +
-			"w\xe4\x9f \x8f\x8b\xdd\xfb;T\xdc=~\x13\xe0?\xef\xf6(N\xd9G\xfa\xb5ɚ\x1c=\x9e\xcfA\xf8\xf7&nN\xf5\a\xf4\xb9\t.\x97w\xf7\x8c\xa0\x80\a\xd4\xc4A\x11\x1f\xd0\xfb\xbb\xa7\f=\x1f˪\xb9\v\x92\xb2hPѼ\xbf{\xce\xd2f\xff>EOY\x82\x86\xf4\xcb Ȋ\xac\xc9\xe2|X'q\x8eޏ\xee\xdc" +
-			"̪rS65`U\x94Y\x91\xa2σ\xa0(\xb7e\x9e\x97ϰb\xdd|\xc9Q\xd0|9\xe2\x9a\r\x16\xf5>\xa9k@'\u007f\xee\xbf\xff\x95\xf6\xfd\xfb\xe0?K\xdcBS\xc5\xc7\xe0i\x12N\xc2Q\xf0ݾi\x8e\xab\xfb\xfb\x1dj6\x82\x16&\xe5\xe1\x8dQ\xf1\x87\xf2\xf8\xa5\xcav\xfb&\x18G\xa3\xd1\x10\xffg\x16|x\xce" +
-			"\x9a\x06U\x83\xe0\xc7\"\t\r\xfc\x9fq\xef\x8b\x1a\xa5\xc1\t\xf7\xa0\n~\xfa\xf1\x03k\xaa&me\xcd\xfe\xb4!\xad\xdc7ϛ\xfa^6|\xbf\xc9\xcb\xcd\xfd!\xae1\xd7\xfb?\xff\xf8\xc3\x1f\xff\xf2\xf7?\x1a\x82\xdck_\x89Y\xcf[\xac\xae\xe16>d\xf9\x97U\x1d\x17\xf5\xb0FU\xb6]\x0f\x0f\xf5\x90\xe8eXg\xbf\xa0a\x9c" +
-			"\xfe\xf3T7\xabQ\x14\xfdf=|F\x9bOY\xe3\xa6^6e\xfa\xe5|\x88\xab]V\xac\xa2K\\5Y\x92\xa3A\\g)\x1a\xa4\xd8XY^\x0f\xb6\xd9.\x89\x8fMV\x16\xe4\xe3\xa9B\x83-\xee\x03V\x05q)\xf2Ϯ*O\xc7\xc1!Ί\xc1\x01\x15\xa7A\x11?\rj\x94\xd0\x1a\xf5\xe9\x80\xd9\u007f9\xa7Y}\xcc\xe3/" +
-			"+\xdc\xe9\xe4\xd3%>\xa5Y9H\xe2\xe2)\xae\aǪ\xdcU\xa8\xae\aO\xb8\xd5R\"\xb3\"\xcf\n4\xa4\x15\xd6O\x88\x88\x86\x9d*γ]\xb1\xda\xc45\"T\xc6hU\x94\xcdw?\x13G\xaaʼ\xfe\xf8F\xb2(\xca\x02\xad\xf7\x88X\x12\xf7\xee\xe7}\x96\xa6\xa8\xf88h\xd0\x01\x93\x1b\xa4\xe1.\xf1y\x13'\x9fH_\x8a" +
-			"t\x98\x94yY\xad\xb0\x99\x8a\xfa\x18W\xd8?/\xf1*\xc6=z\xc2\xcaY\xedK,ι<5D\x04\xa2\xb6ͦ\xfa\x99\x8e\x9b\x8f\xe7MYa\x9d\f\xb1o7\xe5a5:~\x0eR\xfc\x11\xa5\x97\xcd\x00[\xbd,v̂\xcfL\xa8M\x99\xa7\x97t[\xb0B\xea䫬\xc1}L.\xfb\x11/\xc4&[\x8d\xd1a-\xac\x14" +
-			"\xce\x17\xe8\x10D\x17\xfc\xfd\x13\x10y\xf5\xedv\x1b\xad\x99\xdc\xdfFQt\xa9\x0fq\x9e\x03\x1e\x0f\xd8\xdc\xf5\t\x8bq:\x82\xd2\xc5\xec7k\xaag\xa1\xa6\xf5\xb1\xac3b\xbaU\x85\xb0\x92p\x8f\xbd\xca'\x9c\x9a\xf2\xb8\x1aF\xe1\f\x1d\b\xf33\xef7.\x19\x93\xa2\xec\xb0\xe3\x1a\xc1j\xaa\x9fv\xd4R\xab\n\xbbϛ3Q\xe2\x16\x0f" +
-			"\xf3\x153˅\xf9\x96p\xc6\x11\xee\xe34:~\xbe\xec\xab\xf3\xf0P\xfe\x825\xfa\x99H\x9c\x15\xbb\x15\x9f2H\x91to\x0f\xd9S,}\xe2\x88[\x94\x82ħ\xa6\xbc$%v\xfdO\x9b\x14\xbb%\x1a\xd4\xf1\xe1\xa8\r\xb9CY\x94\xd8#\x124\b\xe4ǵ\xd2&\x96\xfa\xb29a\x15\x14\x83\xac8\x9e\x9aAyl\xd8\xe8\xc0*\xc3" +
-			"#b@F!\xf6\xa7\xf8\xcc\f\x95\x15{<|\x1b\xcaA~\x91ÑqR\xf2=eu\xb6ɑh\x81\xb1<ӁM=u[V\a\xe6\xcb\x1cA\x03\x01\x15\xe4g6u\xb2\xf2\xbb\x8f\x03X\x88\xc7\x1ej\x8c2l\xcbC\x86\v\xcfB\xbf\xf1\xf1\x88b\xdcH\x82V\x8c\xc9:9U5\xee\xc2\x11O\xdbx2\xe0M\xfe\x8c\x87" +
-			"T\x8ceL?\xc2\xc6e\xe1\x99WJ\xd16>\xe5\r\xaf\xb4ZQ\vo\xcb\xe4T\x0f\xb3\xa2\xc0s\n\xadg\x97KgZ\x1f\xe34%V\x8d.\x14z\x86>\\`=\xc4\xf9\x05\xf6'٣\xe4\x13\xb6\xbc\xd9\xf5\x18O\x1f\xa0\x97\xc0]\xe4@\xc6>f\xf8\x1f \xb9K\r\xe1xc\xc5\xe9\xb0A\xd5\xddG\xdc-\xde\x1c\xedӰ" +
-			">f\xc5\x10z\x8d\x17\x8f\xa7\x1c\x1d\u007f\xe6=\xa6\x9e\xab\xd9\x0f\x1b+ٻ\xedG\x1cf\x9b\xa1<\xb5:\xf6\xe2\x81\xe5\x94A\xc9\xcfJ\x86\t\x11#wu\xd9[%EIY\xc5dZr\xf5\x88\xfa<\xed\x12\xf6d\xe1#d\xee\xad\xcb<K\x83o\x93\x88\xfc\x95\x03+\x18\x1f\x81\x8d\xc2Ɍ̧\xe1|\xcc\xfe]\x90\x89+G;" +
-			"T\xa4.w\x93#X\x9f6\xc4@\xb7\xa7\xf7\x86\xb8\xbe\x88\vx\xd8\xe7\xf1\xb1F+\xf1a\xcd\td&\xe1\r\xa4\x83f\u007fV\r~\xff\xca\xdey\xf9~\xb5Ax\xaa@\x83\xefW\xf1\x16;\xd4k\xf3Wy\n\x9b\x13\xf1,\xae\xb2\x90\xf88\xdcc\xdd\xe4D?<\xcaV\xbbM\xfc]4\xa0\u007f߰\x8c\x04N\xb9w\u007fB\xf9\x13" +
-			"\"\x01(\xf8\v:\xa1\xbb\x81\xfc>\xf8}\x85\xf3\xcc\x01H\x83@\xabS\xdc*\x9c\x14F\xe1t\xfc0[\x8c\xa6\x13\x11$'\x93\xc9\xda\n\xf98\x8an\x99\x17\x0f\xb4iV\xcd\xdcP68\u007f\xb3vE\tl\x9a\x97]Ĭ\x8f[^ěŚ\xce\xdcʳy\x1a\xc2\xd2\v\x9cf\xd0yOT\x19Of\xe3EbU\xa1\t'" +
-			"˄8^\xa4%\xcd>+x\xee\xb1\x16e3<$\x88\xb7\x06\xc2\x1clj\xad\xb0\x01Y\xf7\x05rXn\xb7x(\xad\x86x\xa4\x18\x819\xa2A\xddH\t\x0e8\x84\xe3\xc0\x14b\xd2\x10\x87\x93cY\xd48qГ><\xfc>\xb3G\x04\x96\x98©\x8bU$f@b\xd0\r\xc9\xdc|\xaaWs,\x01%\xe34\xfa\xb0)p" +
-			"F*\xc7F\x8b\x89\x9dv]\xdb3C\x9a\xa6k\xbd\xbd)\xf4V\x12UY2\x84s\xa9 \x1c\xd7\x01\xc2\xc9\x0f\x9e\xb7\xc9T\xbc\x1e\x96]\x88\x0e\xb23\xd5\xedRS\x92U\x89\x9aP\xb8\xd43\x9c\xdc\xe1L\x89\xd9hH\x92\xb21\x19v\xfc;\xcf\xcah\x91\x9c\xd28\x03\x82\x05\nA\b\x9b\xb1\xae\x86e\x91\u007f9\xcbT0\xde`2" +
-			"\x0e>k.\xd8Q\xa6P#\xd9\xcaj8\x82\xd3\xea\xda\xc8\xef\xd6I\x9e\x1dqN\x994x\xac\a\xfc\u007fo\xa48\xb2Q\xe6\x93d\xce\x14\t\xb7\x83\xc2\\]\x8aW\xe3G\xd8,\xe1\xc2\x11MA\xad\xc9i\u007fm&RL$\xaa\xd9\xfdh\xb0\x1f\x0f\xf6\x93\xc1~:\xd8\xcf\x06\xfb\xf9 \xc4E!.\vqa\x88KC\\\x1c\xee\xe7" +
-			"\xfe\xe1\xcf\xe7\xfcY\x14\x19N9Zk\xc9\x1en+\xa0\xb99nQ|\x98\x88\x0fS\xf1a&>\xcc\xf9\x87PV\ve\xbdPV\fe\xcdPV\re]\\5\x94M\x86\xb2\xcdP6\x1a\xcaVC\xd9l\xa8\xda\rUáj9TM\x87\xaa\xedP5\x1e\x82G\x90g\x98\x9a\xe9\n\x12\xf3\xf1b\xb1\xb8P\xa5S[\x84\xcc\x1e" +
-			"\xb8\xa1\x0e\xaf\x1eѧ\x84\x91\xa5&\xa0%K\xcfJq\xb0w\x0e-\x85.\x85\xa9\u038307\x9f\xe1\x118\xa5\xaeB=\x85;\xd1\x1cJ?\xf2I?\xb5\xcc\b\xac\xe8p\x85y`\x9a.tY1t\x1btnK\xbf \xd2S݃\xc2\t\x99{\x99)`)\x95\x98Y\x06<\xa1Ni?\x88\x1c0\xf6?\x90R\xaa\x8e\xb3\x1e\x9b" +
-			"/\\;\xa0\x94Ě\xa3\f3A\x14P݄9\x8aӳc\"\x035\xe7\xe2+\xf7\xb2\x895\x06\xa7\x97\xdf\x1dP\x9a\xc5\xc1w\ăM\x14\x8b9\x96\xee͙5\x00z\x82\xa7\xb1˅\xeb\xcaz\x88\xc6z\"\x0fރ\xd0x\xfc\x96a&\xd9>\xa0\x89\x9c\x06C\xfc\xe4~\ti\xd4\xceі?\xac\xb1\x98I\xbes\x12]" +
-			"k\x824Z\xc0\x89\t\"\xcfW\x90\xcaJ8\x99\xac\xe5d\xdb/\x90\u038b8\xa0(\x9f\xab\xf8x~\xdeg\r\xa2i&ɗI\x91\x90\xab|FU\x82\xa3\x92\xf9$)\t\x1cx\xc2\t\xb7\x13(\tB\xe2\xf8H\x171~\xb1\x90\x8a¡\a\x1cT\xd23\x98\x00X\xf1\xb1\xca誑\x96/]b\x8dȗaD~\xf40\x8f" +
-			"\x96\x11\xaf^\x9f\x92\x04\xd52w\x9a$\x8b\xf9$\x15\xd59Ѩ\xbe\x99M\xc7\t\xaf\x9e\x15\xdbR\xd6\x1d-\xa2\x87\xad\xa8K(F\xc5\xe9l<_\xf2\x8a\xcfqU`\xab\v\xdaC<O'\x1bQ\x97\x13\xf5\xea\xf3\xf9l$\xdbM\xe3b\xa7H\xf1r:\x9d\x8eEmF\xd3+?L'\xb3\xc9\xf4\x12nv\xa6\xc2h\xb2c\xf9\xa6" +
-			"T\xa3\xaa\xc0\x19\xdaX\xa1O\f\x15ڴA\xe9v\x1b\xa5\x0f\x8c\xa1\xaeV\x1b\x9b\x8c\xd0x3\xa1\f\xa9~\x1dܖ(\xddr\xf1\x80\xa2m`\xbc\xc5PDY\t\x8d\xfb\x06\"㦫ށ]\xa0d3\xa3\f\xb9\r\x1c\x981\xceM\x11\xe3\xa7\x19Æ\xa2\xe9f\xb9\xc1>q\x8cwd\n\"\x8b\xa4\"g\x153\xd8R\xa5M" +
-			"d\x95\vOv 9\x83\xab\x87 -;\xe5\x832\x87\xe1$rŒS\x1eP \xf9/\xfe\\\xd2Ϫ\x1e\x87b\xcb\xe6Y\x8d\xc7tA\x17\x1dS)\x1f\x99\x95Vd\xf6\xac\xc5z$}*ah\x96\xa7v`\x85P\x94:\x9c\xd1)\\U~\xcc3\xf7\n\xaf\xc6t\xa62I69\x92\x92K\xda\xda{\xa2\xc0K\xda\f\xd2\xf4" +
-			"\xec~(\xc0D\xc7⫌-\xac7-q\"͇\xfb\xb2\xca~\xc1,\xe2< \xcc\xf22n\xe8<.\xf2\xe291\"\xce\xce\xe3\x8a\x15\x9bS\xba\x95\x14S\x80,D9NH\xeb\xac^\xbb&k\xa3y]\xee\xd1\x03\xe9=\\\x89\x1e\xd0\xcfi\xdc\xc4C\\\v\x03\xf1\xc3\x1a_\xa3\xe6\x8bp{\x94\x1f\x1d\x1eǞ\x19\x036\x1b" +
-			"\xf3\xdfr\xb2\xfa\x00\x82\xe0\x12?\x96xC\x00\xb5\xe6\xbfNe#\xfd\x84\xfae\x0027\x1a\xdc\xcd\x18\xbe\bgj\x00\b'\x80\xee\xaf\x18\a\xc7U\x1ec\x8fJ\xf6Y\x9e\x0e@\xf9)\xf7\x10JH\xb0\x86\x02\x00\xf2_6@\tK\x04@\x01\xcf\t\xf4g[m\x99\xbdc݁(\xd6jR\xacɘ-;\xcaCH8\xf3շ" +
-			"\xd5o\xff1\x8eF\xd3\xe0\x1fQ\xf4\xfb\xe8\xb7x*\x93x\xfc@\x8e\xfd\xab\x86,\xc2\xe3)\xcfy֡\x0f\xb3\x11\x1cy||\x8b'M1\x0e\x81Q4{E\x96\xbb\xbb\xc4\xf0\xf6\x17\be`\\\\<ʁL4\x88\x8bG\u0603\x89G\xd9N\r\v\xb1\xe9\xaaZ{\xcf\x18\xc4߱6\x16\x10\xd1ҭ6\x16\x10\x02<\x88\xf8" +
-			"N@\xfd\xe8\xb7\x17\xec\x03\xe4G9\u007f\xe6\xcd'|\xfbyNM\xb8\xed\xbf\xa5\xfc\x84\x8a\xbc\x1c\xfcT\x16qR\x0e~(\v\xecVq=\xb8\xfb\xa1<U\x19\xaa\x82\xbf\xa0绁\xfc\x91\x85\xf2\x923\n~T\b\xa6\xda\xfcA\xe6$\x91i,Ƴ)r-\x01-\xb7\xe3\xed\xd4^\xef\xb9`\x11\xfb\xb1\xf6\xe5U\x13\x83\xe9\xe4h" +
-			",\xa0\xef\xe3\x14\xcf\xefYQ\xa3\x06\xcf}d\xa5\x04\xff\x03\x17>\xc3\xf1\xecͺ?\x94\x88\x1c@\xb1#8\x97\x92u#3ҹ\xe4\xa1\xe1\xda\xf8N\u007f\x12\xd3'7\xd1ƒN\xd1\xc6\x03\x1alw\xe2_\x90{\xc6\xfa\x19n*\x14\u007fZ\xd1\xff\xe2i\"g\x85$\xba\xf12\xf2\xbdcuvF\xfe:\x16\xf2\x92$q\x18\x16\xf7" +
-			"%\xd0\x1c'r,\xd5\xea?\xc5\xc1Ћ\xabS\xf1lA\xc0/\xc3F\xb38\xb3\"\xd5\xea\xa4\"+\xfcd\xe9\x9f,\xe8q\x85LH\xb2'\x93\x80\xe1\x97\x15\x83]B2\x06\xe3\x8c\xfc\xc2\xc5\xc7[e\xae_\xb19\x96\x16h\xf3\xf3\xc8ΕHQK\x1e\xa3\xda\xe2\xe53\x9a;\xd8\x15\x96˱\xb3\xc2r\xe1\xa90\x1aG\x91\xb3\xc6" +
-			"hĪ(\xc2p\x9b\x9f\xb2\xf4\xd5z\x1bV峖\x10\rG\xcaW9pȐ\u0600\xc3\xcf\xf5p4\b\xe8\xc7\xfa ?\x1eR\xf91\xdfɏ\x18;Vر\u008e\x15v\xac\xb0\x13\x85\x9d(\xecDa'\n;Uة\xc2N\x15v\xaa\xb03\x85\x9d)\xecLag\n;Wع\xc2\xce\x15v\xae\xb0\v\x85](\xec" +
-			"Ba\x17\n\xfb\xa0\xb0\x0f\n\xfb\xa0\xb0\x0f\n\xbbTإ\xc2.\x15v\xa9\xb0\xa3\b\x18#\x02\u0588\x809\"\x80\x87ƃփ\xe6\x03\xf6\x1b\x01\x03\x8e\x80\x05G\xc0\x84\xa3\xf1\xd9\xde\xf9@\\\x1b,t\xf7sE\xc3\xc1\x80\xff\x00\xf7\x00\xd6\a\xc6\x05\xb6\x03\xa6\x01\x9a\a\x8a\x85z\x83:\xa1\xfd\x05\xcf&\x17P\xaa~WP\xa5#" +
-			"1\xaaG\xe1\x9c\xfdY\x00jĩ\x0f\x93p\xc2\xff(\xeaR\xce \xaa쁗\xcd\xe7\x0ev\vN\x9c=8\xb8\xcd\x05\x11H7\xe3eS\x97pSN\x9c\xb8d\x9bp\xe2\x18\xc8&\x15\xe0\x92M\xe8\xc1%\x1aM\x9d\xb0\xfe\xb8\x99\xa1\xfe\x18i\xc4IN%2H\xc4!NMRȒ#\xa0:)\xe1\x81\x13\x9c:\xa5\x88\x05G" +
-			"8\x15K\x11s\x810e\x9fq\x82S\xc5\x141\xe5\b\xa7\x9e)b\xc2\x11cSr\xa92\xaf\xe4Bs^\xc1\x85\xde\xd8/`\x92R\xef\x89A\xd8H\xd4\xedA(#F\xf1\x98\x83 \"\x86\xf0X\x03#\x96\f\xa0\x1b\x03\x97?\xb0r\x8f-0`\xc1\x00\x1eS`\xc0\x9c\x03L\xa9g\xac\xdcc\b\f\x982\x80\xc7\x0e\x180a\x80" +
-			"\xb1)\xb3P\x94Wf\xae/\xaf\xc8\\[\x9a\r\xd8o\xc4\xc4\n\xdaJ\x044\x86\x80\x8c4\x88\xd3*\x02\x1aiP\xa7y8t\xa9!\xa1\x9d8\xe0A\x038\rƑ\v\r\xe9\xb4\x1cG\xceu\xa4\xddי\x06pڒ#\xa7\x1a\xd2iT\x8e\x9chȱ\xddS\xc3\x04-=\xd5-\xd1\xd2Ѩ\xf7\xba\x98\x91E\x81$\t\xe4@" +
-			" \xc5\x01\x19\fHP@\xfe\x01\xd2\v\x90=\xc0\xdc\x00\xc6}\x1aӭx\xc7J\xcdxG\xeby\xe3\x1dm\xc1\x1b\xef\x88(f\xbc#\x92z\xe3\x1d\xe9\x917ޑ\x9e\x9b\xf1\x8e(\xc6\x1b\xef\x88\x02\xbd\xf1\x8e(ڌw\xc4\x0e\xdexG\xba\xea\x8bw\x98\xe6\x8bw\x92\xe4\x8fw\x12\xe2\x8fw\x02b\xc5;A\xf0\xc7;\x81\xf0\xc7;" +
-			"\x81\xb0\xe2\x9d \xf8\xe3\x9d@\xf8\xe3\x9d@X\xf1N\x10\xfc\xf1N\xea\xc5\x17\xef\x04\xc0\x8ew\x94\xe2\x8cw\x92\xe2\x8dw\x12\xe1\x8dw\x02a\xc6;Q\xee\x8dw\x02\xe0\x8dw\x02`\xc6;Q\xee\x8dw\x02\xe0\x8dw\x02`\xc6;Q\xee\x8dwR\x1d\x9ex'\xe8V\xbcÄ\xaex\a ]\xf1\x0e@\xbb❂z\xe2\x9d\x02t\xc5" +
-			";\x85\xec\x8aw\n\xe9\x89w\n\xd0\x15\xef\x14\xb2+\xde)\xa4'\xde)@W\xbc\x03\xfam\x8fw\nhƻ\xd6\xf5\x10m\xad\x00,\x05\x80'}\xf0 \x0f\x9e\xd3\xc1c8x\xca\x06\x0f\xd1\xe0\x19\x19>\x00Ç[\xfa\xe0j\x05<Vj\x06<Z\xcf\x1b\xf0h\vހGD1\x03\x1e\x91\xd4\x1b\xf0H\x8f\xbc\x01\x8f\xf4\xdc\f" +
-			"xD1ހG\x14\xe8\rxD\xd1f\xc0#v\xf0\x06<\xd2U_\xc0\xc34_\xc0\x93$\u007f\xc0\x93\x10\u007f\xc0\x13\x10+\xe0\t\x82?\xe0\t\x84?\xe0\t\x84\x15\xf0\x04\xc1\x1f\xf0\x04\xc2\x1f\xf0\x04\xc2\nx\x82\xe0\x0fxR/\xbe\x80'\x00v\xc0\xa3\x14g\xc0\x93\x14o\xc0\x93\bo\xc0\x13\b3\xe0\x89ro\xc0\x13\x00o\xc0\x13" +
-			"\x003\xe0\x89ro\xc0\x13\x00o\xc0\x13\x003\xe0\x89ro\xc0\x93\xea\xf0\x04<A\xb7\x02\x1e&t\x05<\x00\xe9\nx\x00\xda\x15\xf0\x14\xd4\x13\xf0\x14\xa0+\xe0)dW\xc0SHO\xc0S\x80\xae\x80\xa7\x90]\x01O!=\x01O\x01\xba\x02\x1e\xd0o{\xc0S\xc0\x1e\x01\x0f\xac\xe7kK\xe2`\xc5\x1b,h\x83\xf5j\xb0\x1c\rV\x9b" +
-			"\xc1b2X+\x06K\xc1p\x99\x17.\xe1\xb2\xe5Y3\xe2\xb1R3\xe2\xd1zވG[\xf0F<\"\x8a\x19\xf1\x88\xa4ވGz\xe4\x8dx\xa4\xe7f\xc4#\x8a\xf1F<\xa2@o\xc4#\x8a6#\x1e\xb1\x837⑮\xfa\"\x1e\xa6\xf9\"\x9e$\xf9#\x9e\x84\xf8#\x9e\x80X\x11O\x10\xfc\x11O \xfc\x11O \xac\x88'\b" +
-			"\xfe\x88'\x10\xfe\x88'\x10V\xc4\x13\x04\u007fēz\xf1E<\x01\xb0#\x1e\xa58#\x9e\xa4x#\x9eDx#\x9e@\x98\x11O\x94{#\x9e\x00x#\x9e\x00\x98\x11O\x94{#\x9e\x00x#\x9e\x00\x98\x11O\x94{#\x9eT\x87'\xe2\t\xba\x15\xf10\xa1+\xe2\x01HW\xc4\x03Ю\x88\xa7\xa0\x9e\x88\xa7\x00]\x11O!\xbb\"\x9e" +
-			"Bz\"\x9e\x02tE<\x85\xec\x8ax\n\xe9\x89x\n\xd0\x15\xf1\x80~\xdb#\x9e\x02Z\x11\x8f\x1f\xcdk;\xf6\xcdO\xbe\xcbmRd\xe7\xe1\x03\xf8\xe1\x8f\xef\x8b!E\x89\xdc\u07b56\xb7y7{\xc7\xceo\xda88\xe9c\x1c\xfcq\xecndu\x1e\x1bz\x97CS\xe1\x0f\x03QD\x8e\xcd\x19Ed\xab\x91Q$+\xa6v\xc5\xd4" +
-			"\xae\xa8\xb6\x97<\xf8wv\x18G\xc1\xb0\x82<G\x8b\xd24u\xf4\xc0<J\xc6\xfak\xecK\x1c;\xb9pۼ\x15\xdcV۬\x12\x9b\xfc@\xaf\xb1]\xe8\xd1\xcc.\x1c%\xeb4/\xcb֖Ӟ-\xa7\xfd[\x96\xa7\xe2\xe8\xce\xd7\v4\xde[\xfa_Hwj+\b=\xdeNO<2\".)Rz\x93\x85\xc3\xc7 \xd1\xf26" +
-			"H\xb4\xfc\xce\xc96mc\xeb\":\xbcr&\xc7Đu_\x9e\x1et\xfb\x9dD\xb9\xba\xa7hv\xef\x14\xcd\ue703g\xda\xc2\xd3A\x03={\x05\xe9\x95\x14\xfae\x14|n\x19+\x9d\xd5M\x95\x1d\x81p\xab\xa2\xd93\x87\xfb\xaeL\xd37.WY\x92\xbf\xa2>\xdd\xff\xaej{w\xd7\xd3=[l\xb2\rp\xd9\xcfI\x1e\xd7\xf5\xf7" +
-			"\xef\xef\xc8\xec|\xf7\xd1:\xc6\xc7\xf2|\xba7M\xecC\x13ΐ\x9f\x0e\x05gԤ\x06\x9f\x01/\xdf\xdf\xce\x1f\x91\xadX\xd6T\x19\x8as\x88\u058ciR\x94!M\x8a2\x98\x97\x9bEQ\xae\xe6\xe1Ƌ\x1d\xb3\xb9\x83¹9(&7+\xa88(&\xb7\xbd\xdf\xeeNgQ*\xe2ǝ=\xa8}\x0f\x94\x06\x01\x03\xcc垏" +
-			"\xba*\xdb9\xb9:\x85\x1e\xc8_\x97\x97\xf0\x030.71I\xc0OL\x12p\x14/C\x9b\x04\\\xc5\xc3P\x94\xbb\x9c\xc5A\x12\xf6u\x90,\x86\xb6\xbf8H\x16C\x97r\xf9y\"\xaf\xc7hg\x8c\xfc.\xd3\x03\xa6c:\x9dFWj\a/g\xcf\"\xb4L\xe6.\xb7!'\x9d\\>\xa3\x95\x03\x87\xd1ʁ\xb7\xb8\xf9\xec=|\xf6" +
-			"N>\xb4\xd0\xe5!f\xb9\xb0\xa6Y\xae\xf3\xb1\x1d\xc3,\xd7\xf98\x15\xc7\x0e\x85y]B\x1d\x14\xf3\xfbC\x17\x06\x00:=\x01\xa8\xad\x8d\x8b\xab+\xc9\x14M\xb6\x13\x97\x0f\xf0\xf3i.70I\xc0\x13L\x12p\x06/C\x9b\x04\\\xc2\xc3P\x94\xbb\x1c\xc3A\x126u\x90,\x86\xb6\x878H\x16Cg\xb0a\xc7\xfd\xbc~\xa2\x1d\x01\xf4" +
-			"\xbbJ\x0f\x98\x8e\xe9t\x18]\xa9\x1d\xbc\x9c=\x8b\xb7\xe3$q\xb9\r;\x86\xe8\xf2\x1a\x83\x02\x9cƠ\x00\x9f\xf1q\xb3(\xc0c\xdc\xdcx\xb1\xcb_l\x8a\xb0\xaeM1\xb9\xd9\xcebSLnN\x85\xb2\x93\x9c^W\x81\xa7;\xfd\x9eҍ\xd2 \x9d~\xa2\xa9\xb2\x9d\x933/\xd9$\x89\xf4\x12x\a\x8b<l\xf0\x99\xef\xafW[\x9a" +
-			"\xa30\x1a\xfdF,\xfb\xd7I\x85P\x11\xc4E\x1a|\xa7V\"\x16\xf3\x05\xfd\x01\xc0b\xeb]\xa8\xa0ۢ\xc1\t\a~Б\\P(K\xd9\xd9!RDD\xc2\b\xb2\x92\u008eBl\xe2\xca}M\x8bݵG\xfeDk\x9dl\xf5\x00]\x8fM\x0e\x90\xfd\xf4\xe7\x00ُ\x81mͥ}\x9ak\x03\x81'D\xe79~w=\xeb\xa1" +
-			"د\x1b\xe7\xb3%\\\x82\xf0\n\xe7|n\xbe\xb6\xa6R\xe7\xb55\x95\x8eo\x96\xf6\xea\x9a\xca\x1a\xb0\xe6Y;\vy\xa5\xa6\xc1\xb1\xd5\xeb\x14}]E\xa0\xe7\xeb*\x025\xdf(\xea\xb5\x15\x81\x92\xc1\xd1]\xed,j/%\x8biV1i\x1b\xb4\xb6\x00\xd7Wt\xb5xM\x97\xf5\x8aƍ\xa2\xd1\xe5\x12\xfe\xf3t\xc0\xdf*\xb5*M\xef" +
-			"d\t\xe0\x89'\x8e\x9eDr]Z\x9cos\x84\x0er\x97\x82\xe4\x19\x90k`\xd47r#\x8c\xc6\x00B\x8fg\xc7ܯߤ\xa2\x9dC\x1cG\x11\xa8\xfe\xb8\xaf\xc0\xaa\xa5L\xf8g\xe4/Y\xba/\xd8I\xb1@\xd5\x18\x84\xc6\xf91@s]\x1b\x06\xba\x01\x0f\xd8\xc1evg\xf8\xb3\xb68\xdb\x1a\x9f>\x90s\x997\x88\xa9\x9d!\xa2" +
-			"\xf7\x05\xe8g\x88\xe6\x91.\xb9m\x0ep\xe7Є\x1e\xaa\x8bsT5괽\xed\x06\xf0\xe2/\x15U\xfdg\x18\xc9\xd1I\xc65\xd8O\xf5;\x17\f_` \xf6\xcf0ϊO\xf6\r\v\x8c\xf8x\x1c\xf0\x0f'\xc7]\x14\x1c\xf2\xf6\b\x1b\x9bI)\x86iV\x1f\xb2\x9a\xde\xfd5Ћ\xc8=^\xc6\x19\xf6\x89\xbb\"\xf6\x81\xbc\xac]" +
-			"\xf59\xc5q\xfe\x8b\xdenKn\x8b\xe4'\b\xa9G\xbbT\xd0yI\xcaZ]\x00I\v\xe7d=@\x9eue\x17\xd4\xe8\xac\x02\xe7\xf0H\x96\xec\x1a\x15\x1d\n\r\x90\xe87\xda0J\xeb\x95+\x86l\x9b\x04=l\xe5\xad\\\xfc\x02\x1c\xc0\xc7-X<G#\xa4\xb5甊_\x97\xc3(]\xb7\xb7\x18\x82mc\x92\xe5\n\xc1\xf8\xed:" +
-			":+\xb7l\xdb\x05\x1a\x91\xfb]t\xa8C<q\x1d\x0fw\x91\xf6\xbb`\f\xe9\x88l\xa9T\x1b\xbf\xbdG\xe3\xe4\x16\x8e\xdc\x16\x93D\x06\xd2!\x9b\xb8\xed\xe7\x18\x17\xc8\x1cBl\x80\xf7\xbb\xf3\xb0uػN\x89G\x01\xa9L\xfe\xaf\x9dB\x8f\xf4\x03\xeb~\x14\x17yH\u007f\xa6\x82\xb3\x94 \x90䂸\x81v_\xc8h\xd6r\x1f\x8e\xa3" +
-			"\x0fD\xa3t\x94\xc23\xf8\x80F\xe6[@2\xda\xc6\xcf_Uy\xc4\xfd\xc0S\xac\xf8\x84\xab\xedv92\xc3\x1f\xabG\xafRi\xbb\x8e&2/(\xf3\xb3y\x8c\xddm\xb0\xbb\"\\ji?\x17\xef\xf8\xe1UפWQ\x9c\xec\xd6\xd5#\xbbǇ\xddg-\x8a\x98\xa4\t\xbf\xcd\x16B\xecY\xde\xe2\x12\x80\xcfC\xfcXs\xe8\xc1\xd6" +
-			"\xaa#\x86\x94\xbc\x9a2\x88\x1cG\xf2ͦa\xe6n\xb1\xd4\x1f\b:E\xea\xcdK\xfb\x11\xf7e\x8e\xab\xb5\xaf\x12F\xbby\x98uw\xf7\xa4\x1f'3'}\r\xef\x12#\xf1m\x9b\xad=\xca\xe4\xb6\x17\x97Z\xb1\xdf\xdc\xf5A\xe4\x02su4,\xad\x80\xdf\xec4ݫ<\xcf\x1a\x04\xe4\x16\x88\xd7't\xb4a\xe1\x9cm\x05斔\xf6" +
-			"\xd3\xe8\xb0I\xa7_\x9b\xc2h\x1b\x0f\xecz\xe7\x17\xba\xadŰs\xf7í\x92^\xc9X\a\x92`\xf5Ud\xea`|\xf6\xab\xb0m\xc2x\x81z\x03s\x01\xe4+i\xbb\xb5\x9d\xabt\xf4j\x12\xbf\xa4\x9d+\xfb\xbe\xff7鸥\x9d+\xfb\xfeJ\x12_\xd7N\x8b\xfb\xbf\xd0\xc5\x1dq\xf0kx\xb8\xaf\x99\xab\x1d\xef5\xe4}A3W" +
-			"\xbbݿE\xbf\xfef\xaev\xba\u007f\x8b~\xf7\x8e\xa4\xa9\xc7\xf4\xddG6\xb0Hh\xd5:\xbfJ\xb6or\xe5\xfd\x85\x05ՋE\xbd\x8a\xa9\x06#\x89ݫ\v\xd3\xca\xf4ܪ\xb7\x8e$\xf8V\xb5\xf6\x0ev/\xd0r{\xa0뭟W\x12\xf5\xf66\xae\xe9q\xcf\xf0\xf6\"\xad\xb6\x05\xe3+z\xfc*\xa2^\xd3F\xbb\xa3\xbfē\xbf" +
-			"\xf2t\xd1\x1aѮ\xf2\xb1\xaf<\x95\xf4\x16\xb4\xd3þ\xbaF[b\xeeU\xfe\xf5\xd55\xea\n\xb6]\x93\xb1Z\x99|\xeb|\xce\a\x14 \xa7ށ\xb7\x00\xeb\xed\x1a\x04\x9d[\xceG\xc0\xea}\x12\x8c\xc1\xb55̓\x04\x9a\xbc\xe2\xb7Ȯ\x95\n\xd7\xce\x02'#\xffv\x82\x9e\r\xf4dн\t\xa1w{\xbd\x18to]\xe8\xdd^" +
-			"/\x06\xdd\x1b\x1e\xae\xd7g\x1b\x83\xeem\x12\xd7\xeb\xb3_{\xee\xcd\x15\xb7\xe8\xb3uwF\xb7\xb7\xf6\x98\xafn\xab߹\x8f\xe3\x16W\xedњg\xf3\xc7-\x8eڣ5ώ\x91[\xdc\xf4\x1aM\xde\xd8Z\x9f\xfa\x1d{Snsі\xbd-튵\x8e\x92]\xab\xd9v\x06\xf6v\x96\x9b\xdb\xebŠC\xbc\xfdK\xfbg2\xe8\x10" +
-			"\xef\x9a\xf6\xdc\f\xac};]\xed\x1bۅ\xaen\xbe\xad~\xc7\x1e\xa3\xeb]\xb7gk\x9e\xcdW/\xea\x9bߒ\x9e\x1d[/\xea[\x97\x1d\xc1\x96X\x91\n\x99?\x04\x8b\x1f\xb8\\\xbf\x8a\xb2c\xbd\x80\x1e8\u007f\xdf7\u007fќ\xbak\xbd\xd5+\xcbM46R\xfe\xe6\xee\xee\x9d\x13\xfb\xd6\xfa=\xca\xca{{\xd6\x03?\x12w\xa5\xc2:" +
-			"G\xeb\xc7<\x8f\xc4\fg6\x1c8rp\xfbU;\xa0m\xfe\x9a⳱w\xc7\x02<\x1aJMz_aߛg\x9b\xf2]\xbb\xe8\xba\xf8\x05\xe1&Nw\xe8l\b\xe5z\xb7\x81\x87\x91[\xc7.\xb9\xf8C\x98-\x9axk\x95\xae\v\xfe\x9a*\x1d\xe3Qqۋ\xae\xd6׳\xbdR\xcb}X\x1a\x8a\x16\xa2\xb9Oa;\x19ݪ" +
-			"h]:\xb9Aͱ\x0f\xcd\xc0\xf8ܙ\xeeRs\x88\u07b2í\x9d\xf3\xb5N݃\xa5\xa1n!\x9a\xed\"|˝\x93\xd7ͮ\xad\t\xc8\xf6\xdc9\xb6\xd6A\x80O\xd7tםK\xd7\xfe\x1d{-l\xafTt'?S\xcb\\([\xcb|\xf3\xa0\xcd\xe8V\x15\xeb\xa2\xc9\r\x84\x8e}\x82\x06ƣh\xb6\x8b\xd05\x1e\xfd;" +
-			"\x10\xdb9_\xa9\xeb>,\xcdɚ\x8bf\xc9,\xb6D:yݪq]@\xb1'ұ\xf5Q\x87x\xf4\xcd\xf6E\xba\xf4\xed\xdfS\xd9\xca\xf8Ju\xf7\xe0hj\x9b\vf\xbf\x86\x90o\xf1t\xb1\xbaU\xd9B\xbcg\x94\xe34N\x1d\xd5\x1a\x83\x8d\xe2\xab\xd1ҳû\xff\xebrЄ\xfc\xed\xb7\xffS\xbc\x8a\xa8\xcf.\xd0.," +
-			"\xebY\x00^\x12g\xe5A\xba\vh\fF\x82\xc10W\xbbEɛg\u05ce\xcd\xff\x14W\x1f$ni\xc1\xe8B0}[\xdf6\xfb,_\x14&\v\xf8\x1b\xb6\xcc\xf7\xeeI\xa0E\xe0\x15\xe4\xe6\u007f\xc5R\x96\x98\x10v>\xc0\x06\xf2r\x0e\xaf\xb0f\x05\x84|\xe6\xc5ʙ$\x15\x14\xe9/\xf7\xba\v\xee\xf4\xdb..\xdd\x1d\xb5\xfa\xe3" +
-			"\x91\x1eHiK\xc6Š\xefD\xc4κ\xc7\xed\xd2\xd7ײ\xd7>Z\xaf\x887^\xfec\xbd\x1f\xe8\x02\xdf]\xc7n\xf2`\xaf4\xfbUv8\x96U\x13\x17\r\x87\x10&\xe0\xce@\r\xb0\xcfR\xf5\x92+r\x13\x88F\xad\xf7\xe5\xb3.\x98F\xce\n\xfe\xfe\xf03\xfd7˳F\x1cs\xe4/r\xa5\xec\xc9\xf6\xdfUt\x1f\x05\xf1\xda" +
-			"\xbaV\x8a]\x10\xa5\xbd\x85\xab\xfb\rS\xe4Y\x875\xe3\x13}m\t\xa4\t\x1eo\xb1\xb5\xd5)\a\xfc\x05\xa5\x97ߑc\x98O\x19z&0~\xac3EOY\x82\xd8F\xc9K\xc8{;\xfc\\\x0f\xe4\xe7\xfa\xa0>\x1fR\xf5\x19\x8fK\xafZ\x15\x1ff\xfb\x01,a\xef\x02u\x14\x99\xd8\xfa\xe0(1k\xcb\"\x13{H\x1d%fm" +
-			"Ydb\xf3\x9d\xa3Ĭ-\x8b\f\x0f7\xf5!\xaf۴\x0e\xdb*\x1d\xf8\xbd\x90\x8ea\x17\x92\r\xee\xa6\xf2҆x\x9cj\x9c\xf6\xd0\xc2M\xea\xafH\xeeǁ5{u\xc15ίg\xc2\xdf<\xab\xbf9\xf6V6\x86H\xb0\xd0\xcd\xd28\x0ff\x1e\x92^.GZC8\xe8\xf4\xb4\x1b@Zv3i-vã\x11\xd8ͪ" +
-			"\xe8\xb7\xdb\xd5\x1d\xebo\xcd\xebY_a\xe3[\x99\xdfnyv\xf7\xbb\xd9\xd0h\xb4\\j-\x1dҾ\xa6\aH\xcb\xf4&\xad\xc5\xf4x\xf2\x05\xa6\xb7*\xf60}\xef\x9e\xdd`\xfb\xfe\xbco1\xfe\xb5\xdco\xb7\xbe\xbc\b\xd9\x11\xe7:\xec\f\x90\x96\x9dMZ\x8b\x9d\xf3\x1d\xb4\xb3U\xb1\x87\x9d\x1d}\xb8\xc1\xa2..\xb7\xd8\xce\xcf\xe7j" +
-			"+Y\x13>K}`\xfc2c\xef\xd53\tg\t\xa6\xd6\x1e,;\xfc\x93\xf3\x04c\xb6\aO\xa97^\xbb%\xe1R\x19ױʊ\xa6+\x11a O\x9d\x0e'\xd7\xc1\x96\x9f;\xc8-\xaeN\xd1\xd0\xdb]\xd5-\x87\xd7\xd1\xfdr/W\x97;Ǆ\x017\x9c\xff\x9a\x96\xbaǍ\xb3\xc2\v\xfav\xd3\b㜸õ\xfb\xd2\xe5" +
-			"\x9bo\x02\xf0\xe7ۺ\xc1\xcf5AX\xa1\x04?\xc2\x04\xe1\xf6T$AX\xc4\a\x14\x9c5 \xf9\x03O\x8e\a\xf3(Z\xbb\x11\xfc\xedс\xfe\xa2\xe8\x80\u007f\x1b\x04\xf2\x95\xd1v}\xf9\xa2\xfd\x80\xdfJc!\xe0\xed*\x01\xbb^\xc5\x06\xe9/\xed\x0f\xe4[\xfb-\xa0\xd0R\x00U\xech\x94\x8e\xec\x80^\xa6\xa3\x11\xbb\xb4\x99\xe5^=" +
-			"\xd2s\xa7\xecU\xe1\xa8\xfa\xff\xa1\x89\x90\x0e\x9f\x0e\xbf\xa2\xaf¶ \xda2\x03;c{}\xf3\x1e\xb7\xceQC\x96;\x88&\xc92\x14Y\x16[\u007fcp\x17\x9f\xde\xddӫ\x91\x1e\xbfa_\xe8N\x06\xf6\x99\xfe\x12\xaepi\xf6\x14\xd0kN\xdf\xdf\xc9\xcb!\xee\x1e5\xae\x10#Wl\f\f\xc5\xedG\x02v\x8cw\x88\xae\x82\x12\xe0" +
-			"\xf9\x1c\x84\u007fCq]\x16\xc1\xe5\x82e\x199\xaa\x1e\x1f\xdfդ\xe9\x1dG\xff\xeb\x84\xea&\xfc\t5\xfb2ŵ\xfe\x03\x1d\xea\xe3\x1a\x92\xfeZ\x95M\xe9\xa4\xf0\u007f\xff\xe7o?\xd2\xe68\xdbw\xf7Gg\xb3\xa4\xe6\x0fe\x8a06\x18\x06\xe4\xdbߛ\xb89\xd5\x1f\xb0C\xd2\xfa\xfej\u007f@uRe\xf4\b\xa5\v\xf9\xee\x1e\xeb\r" +
-			"h\xda\xf8J\x94\x9a\xa5L\xa3\xa8h\xee\xba4\x8c[̶T:\xec0\x97\x8bm!\u008c\xbaӝ\xb2B\x81\xf2@[\xc3w\x99\r\x98W[\xbe\xbe{|\xb7\x1f?\xd2\x06\xb1\xd5ƏF\x0f\xfc\xf5\x89\x8b9\x1a\xa2\xe02\x17X\xfa\xdb\xfd\xa9\xa0~\x9az\xe0\xbc\xe3\x15Y\x12\xf7\xf4]\xe3\x9eg\x82;\x1fQ\xecZ\x11\xed\xba\x86" +
-			"\x96\xa6\xcc\xee\x90(\x83\xb5\x80G\\!\x8a\xc8\xd8d.\xfd_\x98HG*\xf52\f\xf1)\xc8\xcb\x1d\x0fv\x83;\x99x\xee\x1e\xffL\xa7\x1f\xdc\x04\xfd\xa0\xd8{\xe4\xc0l\xae\x92\xe3\xdd}\x9e\xb5\xa9\x1b\x15\xa9G\xc7\xef\xee\xcb\xdcc\xd7\xe3\xe3\x87=\n\xd8t\xd6Tx\xae\x0f\xb2:\xe09C\xb0AI|\xaaQ\xf0\x8e\xbcB\xff\xb1" +
-			"\"#\xfb\xf4\x19\x8f\x9f\xcdi\xf7\ue796\x114Y\xf0\xc7c\x9a\x81\x9a\xea\x848-t\x0fB\xbb\x93\x8e\"\xac ܝ\xc0L'䀩\xd8d\xf1\xbaC\x86\xcf@\xaf7h\xbe\xee\xd4\xd8G\xb5\xb4\x98&\xccBj\xf6\x85\xe5\xd0\xf4:A\x9f\xf0\x8d\x1eqlz%X\xb2K\x93\uf08e!Ԥ\x8f\u007f*\x89z\x9b\xb4\x1b\t" +
-			"\xbbOjю\xb7U\xc4\xd4ꕥ\xfd\x1b:\x94\r\n~\x9f\xa6\x15\xaa\xeb\xeb\xe5f\xf5I\xf5\x17J/\xa7\xd2_\u007fB_\x06\xc1\xaf\x9f\xe2\xfc\x84\x82\xd5{\xa0\"\x1a\xb3[\xe7ض\x06\x80\xf4\xa4\x89Nq\x01\x9e\v\xc6D\xba\\H\xffI\xf5Mu\xff(\a\xf1\xcb\xfa\xde6\xafy\x9c\x14\x13\xe8i\b\xd74s]t\x16N" +
-			"\xe3\x8aG\x1f\xf6x\xf6#)S\xf0\x1c\xd7\xc1\x0e\xe1\xe0\x1f7(\r6_pi\xf2\x89\x10Ȝ\xd9:\xbd\x19±\x8f\xacSx\x1ej\x0ex\xde\xfe\xbf\x00\x00\x00\xff\xff\x15:(-ߥ\x00\x00",
+		size:  43349,
+		compressed: "\x1f\x8b\b\x00\x00\x00\x00\x00\x02\xff\xe4}\xeb\x8e\xe38\x96\xe6\xff|\nu\xd4\xe4v]$\x85|\x0fۑ\x81\xe9\xad\xee\xc14\xd0\xd5\xdb\xe8\xaa]`QS?h\x91\xb64I]F\xa4#\"\xcbл/x\x91DR\xa4$;\xa2\n\vLfw\xa5M~<<<\xe7\x90\x1fE\x93\xd4\xe3\x1f\xfe\xfc\xbf\xbe\xff\xe9\xff\xfe\xe3/^" +
+			"B3\xfc\xf4\xe1\x91\xfd\xe3a\x90\x9f>ݡ\xfc\xee\xe9\x83\xe7y\xdec\x82\x00\x14\x1f\xf9W\x9aR\x8c\x9e.\x17/\xfc\x91\x02z&?\xa1W\xea\xd5\xf5\xe3\xbd\xc8\xe8\x80\x19\xa2\xc0\xcbA\x86>\xdd=\xa7\xe8\xa5,*z\xe7\xc5ENQN?ݽ\xa4\x90&\x9f zNc\x14\xf0/\xbe\x97\xe6)M\x01\x0eH\f0\xfa4\xbb\xb3" +
+			"\v\xab\x8aCA\x89\"*/\xd2\x1c\xa2W\xdfˋc\x81q\xf1\xa2\x16$\xf4\vF\x1e\xfdR\xa2Ow\x14\xbd\xd2\xfb\x98\x10%\x9f\xfd\xb9\xff\xf6\x0f\xda\xf7o\xbd\xffY\x14\x94\xd0\n\x94\xde\xf3\"\\\x843\xef\xeb\x84\xd2rw\x7f\x7fB\xf4\xd0\xe4\x85q\x91}c\x14\xfc\xbe(\xbfT\xe9)\xa1\xde<\x9a͂y4[y?\xbd\xa4" +
+			"\x94\xa2\xca\xf7\xfe\x9aǡ\x81\xff[\x1a\xa3\x9c \xe8\x9ds\x88*\uf1ff\xfe$\xaa\"\xac\xae\x94&\xe7\x03\xab垾\x1c\xc8}[\xf1\xfd\x01\x17\x87\xfb\f\x10\x8a\xaa\xfb\xbf\xfd\xf5\xfb\xbf\xfc\xfdǿ\x18\x8a\xdck_\x99[/\xc7\"\xa7\xc1\x11d)\xfe\xb2# '\x01AUz\xdc\a\x19\t\x98]\x02\x92\xfe\x8a\x02\x00\xff\xf3L" +
+			"\xe8n\x16E\x1f\xf7\xc1\v:|N\xa9=\xb7>\x14\xf0\xcb%\x03\xd5)\xcdwQ\r*\x9a\xc6\x18\xf9\x80\xa4\x10\xf9\x10Q\x90b\xe2\x1f\xd3S\fJ\x9a\x169\xfbx\xae\x90\x7f,\nf\n\x16R\xec\x9fSU\x9cK?\x03i\xeeg(?\xfb9x\xf6\t\x8ay\tr\xce2P}\xb9\xc0\x94\x94\x18|\xd9\x1dp\x11\x7f\xae\xc1\x19\xa6" +
+			"\x85\x1f\x83\xfc\x19\x10\xbf\xac\x8aS\x85\b\xf1\x9fS\x88\x8a\x16\x99\xe68\xcdQ\xc0\v\xec\x9f\x11S\r\xe0\x00\xe0\xf4\x94\xef\x0e\x80 \x96+\x04\xed\xf2\x82~\xfd3\v\xa4\xaa\xc0\xe4\x97oZ\x11y\x91\xa3}\x82\x98'wQ\xfds\x92B\x88\xf2_|\x8a\xb2\x12\x03\x8a4\\\r.\a\x10\x7ffm\xc9a\x10\x17\xb8\xa8v\xb4\x029)A" +
+			"\x85rZ\x83\x1d\x88i\xfa\x8c|\xb0K\x8agT]\x8a3e*0\xb3\x1d\x0e\xd5ϼ\xdf\xfcr9\x14\x15DUp((-\xb2ݬ|\xf5`A)\x82\xf5\xc1'\xb4*\xf2\x93\xf0\xe0\x8bP\xeaP`X\xc3c.\x12y\x90\xefR\np\x1a\xd7\xc9L&\xa6\xbf\xa2\xdd\x1ce\xfb\xc6K\xe1z\x832/\xaa3P}VT\xde}" +
+			"u<F{\xa1\xf7WQ\x14\xd5$\x03\x18+2\x1e\xa2\x8f59\x1f|r.\x95\xd4\xcd\xea\xe3\x9e۹1Ӿ,H\xca\\\xb7\xab\x10\x06\xac\xc5N\xe33I\xb4(wA\x14\xaePƄ_d\xbb\x83(\x9c\xb3\xa44;I\x8b좚<\x9f\xb8\xa7vUQ\xd0o.̈G\\\xbc\xec\x84[j\x11[M0\xceP\xe6-" +
+			"\xa3\xf2\xb5N\xaaK\x90\x15\xbf\x06\x87\xe2\x95i\x9c槝\x1c2XR\x1bގlGr\x1b\x13e\x85:E\xc0\x99\x16u\\@\xe4\x7f>@\xbf\xac\x90O@Vj].+\xf2\x82\x94 F\xbe\xd7~\xdcw֜\xa1\xac>\x9c)-r?\xcd\xcb3\xf5\x8b\x92\x8a\xdeA\x10F1\xf5Y/\x04\x15\x02\x17\xe1\xa84OP\x95R" +
+			".\xa1\xfd\xd2vG!\xa9\xd3\xef9%\xe9\x01\xa3\xa6\x06!\xf2\xc2;6\x8f\xd4cQe\"\x96%\x82\x13\x01W\xe4g1t\x8a\xf4\xbb_|5\xb1B\x04Q#\x8d\x9c\x0fYJ\xef~\xb94\xf6\x05e\x89@\x05\xf2\x18턐}|\xaeHQ\xed\xca\"\xcd)\xaad\x95?Ô\x80\x03F\xf0\x17\xb5\xf26\xf1\"\vAt\x04g" +
+			"Le\xa1ݎ{\xf8X\xc4g\x12\xa4y\x8e*\xa1K?\xbd\r\xa6}\t d^\x8dj\x0e\xbd\xa81\x9c\x17U\x06p\xad\xb6'NP\xfc\xf9P\xbc\x9aM\a0-\x94V*\xe1\xd2v\xe4\u05fd\x19\x7fJ\x96=\xd5PNV\x96\x9f\xb3\x03\xaa\xee~\xd9\xed\x9a\xeax\x9b\x02R\xa6y\xa0F\x8d\x13_\x9c\xa9\x8e\xbf\xc8\x16\xf3\xc8\xd5" +
+			"\xfc\x87@\x15'v\xff\xb1\x809\xa6\b\xc3\xfd\xbbw,\xab\x0e\x9d\xfe\"%\x88\x99\x1a\xd8\xd6dg\x11\x88\xe2\xa2\x02lX\xb2\xb5\x88\xc7<o\x12A\xb4\x89\x116\xf6\x92\x02\xa7\xd0\xfb*\x8e\xd8߶cy\xf3R\xf1Q\xb8X\xb1\xf14\\\xcfſ\x1b6patB9\xb4\x85[ۃ\xf5a\xa3\xe9\xe8\xfdᝲЗ\x92\x18" +
+			"\xaf`P\x12\xb4k>\xece\x06\x1bId\x05Чɥ\xab\xf0\xdbw\x8e\xce\xfa\xdb\xdd\x01\x1d\x8b\n\xf9\xdf\xee\xc0\x91\xa2\xea\xbd\xe5w\xf3\x141&Fe\x17M\x14\x94A\x92\x9e\x12\xcc\xec#Y\xb6:\x1d\xc0ב\xcf\xff~#f$\xea\x90{\xf7\xef\b?#F@\xde\xdf\xd1\x19\xdd\xf9\xedw\xffOU\n\xb0\xafL\x83\x94Z\x97" +
+			"\xe5\xabFl\xb3p9\x7fXmf\xcbEC\x92\x8b\xc5bߣ\xfc\xaf\x8eǣ\x88b_\x1bf\xbb\x91[\xd5M\x1d\xbfE\xbdM\x8aZ\xb5L\xab\x9bQ\xff\xab\xc5b\x03\x0e\x9b=\x13\xa9D\xb6\x9c\x86\x88\xe9\x85\x0fv|\xdck\x8a\xcc\x17\xab\xf9&\xee\x15\xe1\x13N1\x13\x92\xf8fZB\x934\x97s\x8f}\x93\xb6*_=\x16\xad" +
+			"^\xe3\x0e^$\xa8\xd2\xfc$\x9a\xdf \x83\xe2x$\x88\xee\x82y\xf9j\x10s\xc4Iݘ\x12d)\x84\x18\xd5a\x9a\x9d\x82\n\x91\xb2\xc8I\xfa\x8c\xf4I\xdf>\x03\xaf\xe2\x11ALLաK\x14dn@M\xa7\v\xd8\xd8|&\xbbu\xf9*\xb2ir\xce\x0e9Hq\xdb7\x06\\l\xf5\xeb\xbe?2@\b\xf7z}K5Z" +
+			"\x19\xab\x8a\xc9\x10\xc0\xd8\v\xe7\xc4C\x80\xa0 \xcd\xd9P\xbc\x0f\x8a1\xc4H\xb6u\xaa;f\xa68\xad\xe2n@\x91Z\xaf\xa2\x8fl\xa6$|\x14\xb0Iٜu;\xf9]\xce\xcaxR;\xa4I\x01\f\xab\x18\x04!T\x87\xa4\n\x8a\x1c\x7f\xb9\xb4SAp \x05>S\xb4\x97\x8a\x95\xed\x14j\xd6ֲ\vf갺7\xe6w\xfb" +
+			"\x18\xa7\xe5\xaeB1\xfd:\xf2=\xf9\xbfoZu\xdaJEL\xb21\xb3\x99p[r\xf8\xa7N=B\x01Mc\xa9\x1c\xb3\x94j\xb5v\xd8ߛ\x13)\xa1\x12\xb7l2\U000d3e5f,\xfcd\xe9'+?Y\xfba2\xf3\xc3d\xee\x87\xc9\xc2\x0f\x93\xa5\x1f&+?L\xd6\xee\xee/\xc7\xfcU\x14\x19A9\xdbk\x93\xbd:\x99y|" +
+			"n\xee'\xf3\xe6â\xf9\xb0l>\xac\x9a\x0fk\xf9!l\x8b\x85m\xb9\xb0-\x18\xb6%öhؖMf^\xd8V\x19\xb6u\x86m\xa5a[k\xd8V\x1bv\xf5\x86]\xc5aWs\xd8U\x1dvu\x87]\xe5\xa1\xf2\b\xf2\xa2N\xcdt\x035\xe3\xf1f\xb3\xa9\xb9ѹ/B\xe1\x8f0Y\x8cD\xf5\x8c?%\xcczfR\xac" +
+			"Գsg8\xb5u\x16+\x856\x83u\x8dWhn\xbd\xfaX\xf30\xe1\x01\x146A\xb4V\xb5\x9f\xb9\xb4_\xf6ܨx\xd1\x12\nk\xcft]h\xf3bhw躯\xfdf\xf5Q\xda^I\\\xb0\xb1W\xb8BM\xe5\x1a\v\xcft\xa9\xf3%o\a\xd3C\xe5\xfe\a\x96\xca\xcdqѹ\xb9\x96\xd6QR\x19ה-\xcdx\x91" +
+			"\xc7m\x13b\x04\xe0\xc52\x90)%\xd7\xcdW\x19e\x8b^\x1f\\\xd6\xff\x9a!\x98\x02\xef\xeb,\xcd\xe5\xf0\xbaY?\x94\xaf\xdf\\D\x05JKf\xe5k]K[\xf5\x1e\xa2W\x1f\xf9\x83\xb7\x1f\x1a\x8f\xdf-\xcd\xc4\xc7\a\xb4h\x87\xc1p\x8e\xb2:䬍\xd1Q>\xac\t\xced\xdfe\x16_kR\xf3x\x82̌Q\xce&iJ" +
+			"\xaeH\x91\xd9l-'=~Q\xf3e\x92\x04\xe4\xc5K\x05\xca\xcbK\x92Rħ\x99h'\x92\x1a\xbd\x8a\x17Tŀ \xf3I\xb2͐\xc0sYځmF\xa31(\xf9\"Ư=d\x97#\xa1ٙ\"xQ\x06\x00\x91\\V)_5\xd2\xe6K5\xd02\xe52L3?zXG\xdbH\x16'\xe78F\xa4\x9d;-\xe2" +
+			"\xcdz\x01k\xa0e\x1a\xc5\x0f\xab\xe5<\x96\xc5\xd3\xfcX\xb4eg\x9b\xe8\xe1X\x83.\xc7(\xb8\\\xcd\xd7[Y\xf0\x05Ty\x9a\x9f\x9a\xbc\a\xb0\x86\x8bC\r\xb4L\xbd\xf8z\xbd\x9a\xb5\xf5B\x90\x9f\xba,\xb0].\x97\xf3\x1a\xa8yz\xe1\x87\xe5b\xb5X\xd6\xe1\xe1d\x1a\x8cOvz\xb1ٚ\xb1+ \x05\xf6\xb1\x8d=\x0f\xa7\xd6" +
+			"\x9a}\x10<\x1e#\xf8P\x03\x05\xe5\x14\x18\xcf\xd0\xfc\xb0\xe0\x02\xb9}-Ҷ\b\x1e\xa5z\x8a\xa1\xfb@p\x84[6M9\x9cZ\x8b\xbb:b\r\x14\x94S\xe0q\x83\xe2Ê\v\x94>\xb0`\xe6\x10AT\x83\x0e\xe4\x14\x87\x96\x87\xeda[\x87%8\xa1@,\x926s\xd6f\x04\xdbv\xd3&\xb6\xca\xe5E\x9e29SW\x0f\x95" +
+			"i\xd9\x19\xfb\x05V\xe9$\xb2q\xc9\x19{\x1c\xc8\xfe{\xc6^\xc1?w\xe5$4\xaaC\x9c\x12\x1a\x9cs\xbe\xe8\b[\xfdب\xb4c\xa3'i\xd6#\xf9S\x89@\x8by\xea\b\xb6Q\x8a\xe7\x06+>\x84w\x85\x9fpj_\xe1Մ\xae\xba\x99\xa4\x18\x1cYJ\r\a[\xcf\fXC\xeaCx\xb1?\x14ԐZ\x16_[n\x11" +
+			"\xad\x19\xe0\t\x88\x83\xa4\xa8\xd2_\x8b\x9c\x02\xec1a\xb8\x00\x94\x8f\xe3ͼx͜\x18c\x04*\x91l\x0e\xe9\xbdI1\a\xb4\x89\b\xe3\xb4$)\xd9\xdb\x06k\xa3z]\xef\xd9\x03k\xbd\xba\x12\xed\xf3\xcf\x10P\x10\x14UzJs\x80\x03\x91\xd3,\xc2%\b\x97{\xd7z\xb5'Fc\xf9[NJ2\x85\x04\xb7\xd1ǽ\x93\x02\xb8" +
+			"7\xff\xeb\\\xd06Nx\\z\xca̍\x93\xbb\xc9\xe1\x9bp\xd5u\x80&\b\xd4\xf0\xef\x04{\xe5\x0e\x03B\x838I1\xf4\x95\xf43vd\x14jF\xaf+(@\xf9ˆ\x92\"&\x02J\x82\x9c\x13\xe8϶\xda2\xfbȺ\x033l\xaf\xcafMƬْ\x1e\xaa\x19\x17\xb9\xfa\xb6\xfb\xe3\x7f̣\xd9\xd2\xfb\x8f(\xfaS\xf4" +
+			"\xc7:\xec\xf0A\x85\x9eQET\x11ay\xc6X\xce:\xf4n6S{\x9e\xec\xdf͓f\xd3\x0f\x15\xa7h\xfe\x8a\xf6\xfd\x19L_\rg{\x15\xa5\f\x8cM\x8a\xc38\xaa\x10\rb\x93\x11N\x10\xe20\xf6\x1f\x87\x9a\xc6WՆ[& \xee\x86\r\x89P\x11\x03\xcd\x1a\x12\xa1B\x94\bb\xb1\xe3\xf18\xfac\r \xac\x18\xeb;g" +
+			"\xder\xc0\xef?\xcfu\x03\xee\xf0o)?\xa0\x1c\x17\xfe\x0fE\x0e\xe2\xc2\xff\xbe\xc8I\x81\x01\xf1\xef\xbe/\xceU\x8a*\xef\xef\xe8\xe5\xceo\x7fd\xe1\xb2\xda\x11e^\xbezKm\xfc`cR3\xd3\xd8\xccWKd[\x02\xda\x1e\xe7\xc7e\x7f\xbd\xa7\xfe|\x80\xd3D\xbb\xe6U\vC\xe8\xa24\x16\xd0\x13\x00\x8b\x97]\x9a\x13D\xbd\xc8" +
+			"c+%^\xe4\xa9\v\x9f\xe1|\xf5\xcd~:\x94\xa9\xec\xa9jG\xeaX\xca֍L\xa6\xb3\xe9\xc3\xe9\xda\xf8\xce\x7f\x12\xd3\a\xb7\xa6\x8e-\x1f\xa2\x8d\a4\xb5ޅ{A\ue968`p\xa8\x10\xf8\xbc\xe3\xff\r\x00\xc6\"\x91\xb1\x9bLc\xdfGVgW\xec\xafe!/\x8ec\x8bc\xcb\nyZ\xe0D\x96\xa5Z\xfd\xa78\x95z" +
+			"\xcb\nq\xf5\xf6C\xbf\f\x1b\xd5FuȊ\x91\xb8*0\xe6K\xfflAO\x1ad\xc1&{\xed$ \xf8\xb2\x13\xb0:d}\x10\xa4\xec\x17.\xd9\xdf*s\xfdJ\x8c\xb1<A\x1b\x9fg\xfd\xb9\x12K\x1a\x98\xc7tu\xc9\xf4\x15\x9f;\xf4\vl\xb7sk\x81\xed\xc6Q`6\x8f\"k\x89\xd9L\x14\xe92\x82#>\xa7\xf0\xddZ\x1b" +
+			"Vŋ6!\nf]\xacJ` \x90q\x81\x83W\x12\xcc|\x8f\x7f$Y\xfb1\x83\xedG|j?\xbe\x92`\xdea\xe7\x1dv\xdea\xe7\x1dv\xd1a\x17\x1dv\xd1a\x17\x1dv\xd9a\x97\x1dv\xd9a\x97\x1dv\xd5aW\x1dv\xd5aW\x1dv\xdda\xd7\x1dv\xdda\xd7\x1dv\xd3a7\x1dv\xd3a7\x1d\xf6\xa1\xc3>t\xd8" +
+			"\x87\x0e\xfb\xd0a\xb7\x1dv\xdba\xb7\x1dv\xdbag\x91\xe2\x8cH\xf1F\xa4\xb8#R\xf0\xaa\xf3T\xef\xa9\xeeS\xfc7S\x1c8S<8S\\8\x9b_\xfa;\x1fXh+\v\xdd\xd3B\xd1\b\xb0Wb\v\x8fWbs\xee+\xb1\xb9\xe6\x95\xd8\f\xab\xdaM\xb5\to\xaf\xf2lR+\xa9\xdd\xef\n]\xea\xac\xe9ճp-\xfel" +
+			"\x94\xdcH\xe6>,\u0085\xfc\xd3\xe5n\xdb\x11\xa4K{\x90i\xeb\xb5E\xdcFf\xae\x1e,\xd2\xd6M\xa6\xa2\xddJ\xa6-m\xca-e\xe6¦\xdbBf\xce\x15\xddZ\x03\xd8tk\xec`S\x8dO\x9df\xf3\x8bts\x14\x99Y3\x99e5\xa2\x80D\x12b\xb5$\x87l%b\xb322\x1ed\x86զ\x1c\xb1\x91\x88\x95S\xfbu" +
+			"\x830u_Ɍ\xa5S\xf5\xa5D,\x9c\x9a/$bnjޚ̩yc9\xa7\xe2\x8d\xdd\xc4/`m\x0eI\x98CDO\xd4\xfd\xc1rf\"\xc7\xe1\x0e\x86\x88\x04\xc2\xe1\r\x92\x04[\x01НA\x92\xe0A\xa4;|A\x92`#\x00\x0eW\x90$XK\x80\xa9\xf5J\xa4/\x9dJ/\x05`\xe1\xd4y!\x00sS\xe7\xc6P" +
+			"N\x9d\xa5\xbd\x9c*Kki>\x10\xbf\x113/h+\x11\xaa3\x1a\xc8L\x83X\xbd\xd2@#\rju\x8f\x84n5\xe4f\xd5\x03<h\x00\xab\xc3$r\xa3!\xad\x9e\x93ȵ\x8e\xec\xb7u\xa5\x01\x96\x03M]j\xc8\xc5@K\x17\x1ar\xdeo\xa9ႁ\x96\xea\x9e\x18hh4y]̘E)\x93$e\x0e\xa4Lq\x94\x19" +
+			"\x8c2AQ\xe6\x1f\xca\xf4B\x99=\xa8s\x03\x95\xf7If\xe3;\x91j\xf2\x1d/\xe7\xe4;^\x83\x93\xef\x98*&\xdf1M\x9d|\xc7Z\xe4\xe4;\xd6r\x93\xef\x98a\x9c|\xc7\f\xe8\xe4;fh\x93\xef\x98\x1f\x9c|ǚ\xea\xe2;\x929\xf9\xae\xcdr\xf3]\vq\xf3\x1d\xc9\x1c|G\xb21\xbe#\xd9\x18ߑ\xcc\xc1w$\x1b" +
+			"\xe3;\x92\x8d\xf1\x1d\xc9\x1c|\xd7d\xb8\xf9\xae\xb5\x8b\x8b\xef\x1a@\x9f\xefH&\a\xe9\x1eߵ9N\xbek\x11N\xbe#\x99\x9d\xefH6\xc2w$\x1b\xe1;\x92\xd9\xf9\x8ed#|G\xb2\x11\xbe#\x99\x9d\xef\x9at'ߵ\xe6p\xf0]\x93\xdf\xe3;\x92\x8d\xf2\x9d\x02\x19\xe3;\x05:\xc6w$\x1b\xe1;\x92M\xe5;\x92M\xe5;" +
+			"\x92\x8d\xf0\x1dɦ\xf2\x1dɦ\xf2\x1d\xc9F\xf8\xae\x03\x8c\xf1\x9db\xdfa\xbe\xeb\x80&\xdf\r\xae\x87hk\x05\xcaR\x80\xf2\xa4\xaf<\xc8+\xcf\xe9\xcac\xb8\xf2\x94\xad<D+\xcf\xc8\xea\x03\xb0\xfap\x9bA\x1b\xe1\x89T\x93\xf0x9'\xe1\xf1\x1a\x9c\x84\xc7T1\t\x8fi\xea$<\xd6\"'᱖\x9b\x84\xc7\f\xe3$<f" +
+			"@'\xe11C\x9b\x84\xc7\xfc\xe0$<\xd6T\x17\xe1e\xd0Ixm\x96\x9b\xf0Z\x88\x9b\xf02\xe8 \xbc\f\x8e\x11^\x06\xc7\b/\x83\x0e\xc2\xcb\xe0\x18\xe1ep\x8c\xf02\xe8 \xbc&\xc3Mx\xad]\\\x84\xd7\x00\xfa\x84\x97A9J\xf7\b\xaf\xcdq\x12^\x8bp\x12^\x06턗\xc1\x11\xc2\xcb\xe0\b\xe1e\xd0Nx\x19\x1c!" +
+			"\xbc\f\x8e\x10^\x06\xed\x84פ;\t\xaf5\x87\x83\xf0\x9a\xfc\x1e\xe1ep\x94\xf0\x14\xc8\x18\xe1)\xd01\xc2\xcb\xe0\b\xe1ep*\xe1ep*\xe1ep\x84\xf028\x95\xf028\x95\xf028Bx\x1d`\x8c\xf0\x14\xfb\x0e\x13^\a\x9c@x\xcaz\xbe\xb6$\xae\xacx+\v\xda\xcaz\xb5\xb2\x1c\xad\xac6+\x8b\xc9\xcaZ\xb1\xb2" +
+			"\x14\xac.\xf3\xaaK\xb8\xf8dc<\x91j2\x1e/\xe7d<^\x83\x93\xf1\x98*&\xe31M\x9d\x8c\xc7Z\xe4d<\xd6r\x93\xf1\x98a\x9c\x8c\xc7\f\xe8d<fh\x93\xf1\x98\x1f\x9c\x8cǚ\xeab<|r2^\x9b\xe5f\xbc\x16\xe2f<|r0\x1e>\x8d1\x1e>\x8d1\x1e>9\x18\x0f\x9f\xc6\x18\x0f\x9f\xc6\x18\x0f\x9f\x1c" +
+			"\x8c\xd7d\xb8\x19\xaf\xb5\x8b\x8b\xf1\x1a@\x9f\xf1\xf0I\x0e\xd3=\xc6ks\x9c\x8c\xd7\"\x9c\x8c\x87Ov\xc6ç\x11\xc6ç\x11\xc6\xc3';\xe3\xe1\xd3\b\xe3\xe1\xd3\b\xe3ᓝ\xf1\x9at'\xe3\xb5\xe6p0^\x93\xdfc<|\x1ae<\x052\xc6x\nt\x8c\xf1\xf0i\x84\xf1\xf0i*\xe3\xe1\xd3T\xc6ç\x11\xc6ç\xa9" +
+			"\x8c\x87OS\x19\x0f\x9fF\x18\xaf\x03\x8c1\x9eb\xdfa\xc6\xeb\x80=ƓG\xf3\x86\x8e}˓\xef\xed6)Z\x94\xbb\a\xe5\x87?\xb9/\x86%uۻ\xf6\xe66o\x9aXv~\xf3ʕ\x93>\xc6\xc1\x1f\xcb\xeeFQ\xe6\x89\xf2\xbb\x1ch\xf5D\x13\xbfIb\xc7挤cQP#\xa9-\b\xfb\x05a\xbf`\xb7\xbd\xe4\xc1" +
+			"\xbd\xb3\xc38\nF\x8b\xd2q\xb4\bBhi\x81y\x94L\xb4\xd7ؗ8\xb7J\x91\xbe\xf9\xae\x91\xb6;\xa6U\xb3\xc9Oiu\\`\xe6\xder\fǳ\xf5<\xa7\xc8\xc1\x9a\xe1Ě\xe1\xf4\x9a\xe1E\xb1iT\xab\xce\xfb\x8e\xffWͷZ\xcb\v\x1d\xd1\xceO<\x8a\xcc .r\xc8o\xb2\xb0Ę\x9aً65\xb3\x17wV" +
+			"\xb1pH,\x1c\x12\xdbE\xe5\xaa\xed\x13\x81h~{z\xd0\x1ew-\xcaּ.\xafߺ.\xaf\xdf8\x8bL8 \x13\x0e\xc8|\x17\xed;-\xf4\xcb(\xe4\xd82\xeflFh\x95\x96\x8ar\xbb\x9c&\"\xe0\xbe. \xfc\xe6b\xddA\xb7=n\x9b\xf2|\xff{Wڹ\xbb\x9e\xef\xd9\x12\x83\xad\x17\x17\xf8\xe7\x18\x03B\xbe\xfdt\xc7" +
+			"F\xe7\xbb_z\xc7\xf8\xc4<\x9f\xefMk\xf6\xa15\xc1\x80\xcfY.\x05Qh\xc8\xf1ezr\xbb|\x84q\x7f\x84\x82as\x0e\xb17b\x9a9\x9d#͜\xceaNi\x89SZ\xe2\x90&\x93-\xa3\xb9%GJ\xb3\xe4\x98\xd2\x12\xa7\xb4\xc4)-q\xfb\xdd\x1a,\x9d\x89\xe4qg\a*\x99\x80\xd2 J\a\xb3\x85\xe7\x93n\xcaa" +
+			"I\xb6F\xa1\a\xf6\xd7\x16%\xf2\x00\x8c-L\xcc,%N\xcc,%P\x9c\x02\x13\xb7\xc0\xc4%\xb0I\xb7\x05\x8b%\xab\xf1\xaf%\xab'0q\vL\xdc\x02\x13\xf7y\"g\xc4hg\x8c\xdc!3\x01\xa6cF\x83F7\xea\x88,k\xcb\"\xb4\x8d\u05f6\xb0a'\x9dl1\xa3\xa5+\x01\xa3\xa5+\xd1b\x97\x938\xe4$V9<\xd1" +
+			"\x16!fz\xe3M3]\x97\x938\xe4$\x0e9\x89\xfbP\x983$\xba\x83b\xeex\x18\xc3(\x80\xd1HP\xcc6$\xc5֔x\x89\x16ǅ-\x06\xe4\xf94[\x18\x98YJ$\x98YJ08\x05&n\x81\x89K`\x93n\v\fKV\xe3SKVO`\xe2\x16\x98\xb8\x05&\xee\xe3~\xce8ю\x00\xbaCe\x02Lǌ" +
+			"\x06\x8cn\xd4\x11Y֖\x81\xe3<\x8ema#\x8e!ڢ\xc6\xc8Q\x82\xc6\xc8Qb\xc6%-qJK\x1c\xd2d\xb2-^\xfa9\x8dw\xfb9\xa6\xb4\xc4)-qJK\xdc'9\x9d\xa1\xa2\x9e\xeetG\xca8J\x83\x8cƉf\xcaaI\xb6F\xa1C\x1c\xb7Q\xa2\xde\xc1\xd2\x1e6x\x95\xfb\xeb\xbb-\xcdQ\x18\xcd>6\xcb" +
+			"\xfe$\xae\x10\xca=\x90C\xef\xebn%b\xb3\xde\xf0\x1f\x00zb\x9d\v\x15|[\xb4r\xc2A\x1et\f2Ҟs\x94g\x87X\x12S)I\xf9J\x8a8\nq\x00\xd5~\xf0!\xa8\xd3\xe1I\xa4\xf4O\xb6:\x80\xb6\xc7&\v\xa8\xff\xf4g\x01\xf5\x1f\x03\x87\xaa\x83S\xaa\x83S\xaa\x83\xf6s\xfc\xf6r\xbd\x87\xe2h\x1c\xaa\x1aI]" +
+			"\x82\xf0'\x94\xec,wm\xc9Μז\xecl|\xb3\xb6\xf0fm\xb5\x92\x17\xed,䕖V\x8e\xad^gh\fn\xd2\xfcڂ\x8a\x99oT\x15ު\xaaZ\xf0\xa2\x9dE\x8d\xea\xe9\n(B\x86:m_\x81\xeb\v\xdaj\x84\xb7\xd6h,\xe2\xb0U\xeb\xf0?\xcf١\xa0U\xb7*\xcd\xefd\xf1\xd4\x13O\x12\xbd\x88\xcaW\xe3" +
+			"|\x9b\x85:\x10Rdz\xec\x1a\x98\xee\x1b\xbb\x11F\x13\xa0Bˋe\xec\xd7oR\xd1\xce!ΣH)\xfe\x94Tʪe;\xe1_\xb1\xbf\xcaI1\xaf+\xe1\x9b\xe7ǔ<۵aJ3\xd4\x03v\xea2\xbb\x95\xfez[\x9c\xfb\x16_>\xb0s\x997\xa8\xa9\x9d!\xe2\xf7\x05\xe8g\x88\xd6Q\xf9:\xec\x8e\xce\xc0\xeb\x05?" +
+			"T\a0\xaahw\xda~5x\xf1WǪ\xee3\x8c\xec褐\xea%K\xfd\xce\x05#\x16\x04H\xfc\x13\xe04\xffܿaAd>\x95\xbe\xfcp\xb6\xdcE!!ߕje\xabV\x8b\x00\xa6$K\t\xbf\xfb\xcbדR6\x03\xd0-\xb8\xb0\x17\xf4\xc2\x18\x17\xc4V^\xe6X\xce\x7f15\xd8\x1dx{!9\xe0\x11m3\xc1" +
+			"\xe8%)\xfb\xee\x02H\x9e\xb8f\xeb\x01{\xfd\x82\x1a]\x94g\xed\x1e\xf1V\\\xa3\xa2CU\a\x187ڈ\x9c\xc1+W\f\xdd\x0e1z8\xce\xf6\xfa\x058\x8a\x1c\xbbb`\x8dfH\xabϪ\x95\xbc.G\xe4\x8c\xdd\xdeb(v\x04l\x96\xbb\xd7o\xd7\xd1E\xd9u;n\xd0\xec\xb02\xa1\x16\xf5\x9a\xebxD\xce\xc8]0\x86v" +
+			"L78\xdb\xeb\xb7\xf7h\x92\xecʱ\xdbb\xe2\xc8@Ztkn\xfb)A\x8e\xb0\xedX\xfe\xc4;\x0f\a\xbb\xbd\xed\x94x\xe4\xb1\xc2\xec\xff\xda)\xf4H?\xb0\xeeFI\x95\x03\xfe3\x95:J5\x19lr\xc1\xc2@\xbb/d\xb6\x1a\xb8\x0f\xc7\xd2\x06fQ\xdeK\xd53\xf8J\x1e\x1bo\x95,\xa3\xee\xa7\x10VE\t\x8b\x97\xdck" +
+			"?\x05\xb48\x9d02\xe9O\x94\xe3W\xa9\f]G\x13\x99\x17\x94\xb9\xc5<\x01{\x1d\xe2\xae\b\x9bY\x86\xcf\xc5[~x\xd5-\xe94\x94̶\xdb\xeaI\xdc\xe3#\xee\xb3n\x92\xf8?\xed\xb5\xb6*\xa4?\xca\xf7\xa4x\xca\xe7 \xa5(\x9b \xb6W\xa6\xe9R\xedՔ^d9\x92oV\xad\xce\xdc{\"\xf5\a\x82Q\x95&\xcb\xd2" +
+			"~\xc4}[\xe0j\xf5c\xe0\xae^\x9du\x8f\xb7d\x9a$sN\xfa\x1e\xd1\xd5\xf4\xc4\xef\x86|\xed0\xa6\xf4}T+\xf8\xef\xf4Nd\x03KsP1\xadP\xbf\xf5\xa7\xe9N\xe39\xd6 Ti^\xf3\xfa\x84\x91:z8k]\x9e\xb9%e\xf84\xbaZ\xa55\xaeMeT\x90\xa5\xdc\xe5\x8da\xdb\x138\xba\xfb\xe1VM\xaf\x14\xac" +
+			"\x03\x19Y\xfd&:\x8d\b\xbe\xb8M84`\xbc\xc1\xbc\x9e\xb9\x00\xf2\x1bY{\xb0\x9e\xabl\xf4n\x1a\xbf\xa5\x9e+۞\xfcN6N\xde\xcd\xc6\xc9\xefd\xe3db\xf8\xbf1\xc4-<\xf8[D\xb8\xab\x9a\xab\x03\xef=\xf4}C5W\x87\xdd\xefb\xdf\xe4\xbd\xec\x9b\xfc>\xf6M,\x93\xa6\t\xc3\xf7\x14\xdd:L\xbf\xd4\xe5]f\xfb" +
+			"\xa6T\xd9^5\xa1z\xb3\xaaW\t\xd5`lb\xf7\xee\xca\f\n\xbd\f\xdamd\x12|\xabY'\x93\xdd\x1b\xac<Lt\x93\xed\xf3N\xaa\xde^\xc75-N~\a\xab&\xefc\xd5\xe4w\xb0\xaa\x95\x85\xdfw\x80\x98\xcajo\f\xe4\xb7\x0f\x1e\xef\xa3\xe8\xcdU\\\x17a\xbf\xb9E\x93w\xb1h\xf2\xdb[4\x99\u0380\xb5\xfe\x84ͷ" +
+			"\xd1[\x9f\xf3\x95\x1cEO\xbd\x01\xdf)Xg\xd3T\xd0e\xe0|\x84Z|\xca\x04ÿ\xb6\x84y\x92@ӷ\xf9-rl\xa5¶\xb3\xc0*Ƚ\x9d`b\x05\x13\x05\x8coB\x98\\\xdf$\x01\xe3[\x17&\xd77I\xc0\xf8\x86\x87\xeb\xed\t\xaf\xb3'|\xab=\xe1u\xf6\x84o\xb5\xe7\xe0\xee\x8c\xf1h\x9d0^\xddV~t\x1f" +
+			"\xc7-\xa1:\xa16\xc7\xe6\x8f[\x02uBm\x8e\x1d#\xb7\x84\xe95\x96\x84o\xb4$\xbcʒ\xf0\x8d\x96\x1c\xdc\xdb2l\xd8\xdeQ\xb2k-;,\xa0g\x9a\xdb\xeb\x9b$`D\xbd\xe4\xad\xedK\xaek_\xf2\xd6\xf6%\xbd};c\xf5\x1bۅ\xae\xae~\xa8\xfc\xc8\x1e\xa3\xebCwbm\x8e\xcdWoj[rUے7\xb6m" +
+			"̏\x9d\xbc\xeee\xa1\xf6\x1f\x83\xac\xbf\x8a\x8ac\xbdJ\xbeg\xfd}?\xb2m\xd0\xe9\x97\xfaN/\xdcn\xa2\xe9#\xdb\xdf\xdc\xed\xad\xb3b\xbf\xeb\xfd\x1e՛\xf7N,\xa7\xfcH<6\x15\xd6%\xf6~\xcc\xdbE\x038\xb3b\xcf2\a\xef\xbfjG\xa9[\xbe\xa6\xf8b\xec\xdd\xe9\x01\x9e\f\xa3N\xbf\xc2~\xb2\xcc!\xe3\xdbvэ" +
+			"\xc9\xf3\xc2\x03\x80't1\x94\xb2\xbd\xdb\xc0!\xc8n\xe3'\xa7\x8d-\xaa5o\xad\xd2m!_S\xa5c\x1c&\x1ez\xd1\xd5\xfez\xb1WZy\x8aH\xc3Ѝj\xf6S\xd8VA\xb7\x1aZ\u05eeݠfهf`\\\xe1\xccw\xa9\xed\xaf\xda\xe16,\xf9ڠ\x9e \xd20w\xa3Z?D\xe4\x96;\xab\xac\x9bC[SP" +
+			"칳l\xadS\x01.[\xf3]w\xfb\xabv\xec\r\x88\xbd\xd2У\xf2L+K\xa5\xfaV\x96\x9b\a\xfb\x82n5\xb1\xaeZ\xbb\x81вO\xd0\xc08\f-v\x11\xee\xafځ8,\xf9J[O\x11i\x0e\xd6R\xb5\x9e\xce͖H\xab\xac[-\xae+\xd8쉴l}\xd4!\x0e{\x8b}\x91\xfb\xab\xf6T\x0e\n\xbe\xd2\xdc" +
+			"\x13$\x9a֖\x8a\xf54n\xb6x\xdaD\xddj\xecF\xbd\x17\x84\xf1E9\xaa5W6\x8a\xeff[\xc7\x0e\xef\xe9\xaf\xcbA\v\xf6w\xda\xfe\xcf\xe6UDSv\x81\x8eaE\xcb<\xe5%q\xbdy\x90\x1e\x02\x9a\x80Y# \xc0\xddnQ\xf6\xe6ٽe\xf3?Ǒ\xac\xc5m{0\xbe\x10\xcc\xdf\xd6wL_\xdb\x17\x85\xb5\t\xf2" +
+			"\r[\xe6{\xf7Z`/C\x16h7\xffw\"\xdb\x14\x13\"\xce\a\xf4\x812]«⥅\xb0\xcf2\xb9\v\xa66WI\xd2_\xeeu\xe7\xdd\xe9\xb7]\xd4\xe3\r\xed\xb5ǡ\xbd\xa2e_3\xa9\x06\xabkw(hR\x87\xe2\xf5\xb5ⵏ\xbdW\xc4\x1b/\xff\xe9\xbd\x1f\xa8V\xdf]'n\xf2\xe0\x9f\xbd?\xa4YYT\x14" +
+			"\xe4TB\x98\x10\xe5\xce@\r\x90\xa4\xb0{\xc9U^\xe4H\xcb%I\xf1\xa2+\xa6e\xa7\xb9|\x7f\xf8\x85\xff\x9b\xe2\x946\xc7\x1c\xe5\x8b\\\xb9\xf8c\x91\xd3]t\x1fy`\xdf\x7fg\x14\x87io\xe1\x1a\x7f\xc3\x14{\xd6\x11ոT\xdf\xf7\x14\xd2\x14\a\xc7c\xfaڝr8\xa6\xaf\b\xd6\xff\x1ad$xN\xd1\v\x83\xc9c\x9d\x10" +
+			"=\xa71\x12\x1b%\xebP\xb66x%~\xfb\x99d\xdd\xe7\fv\x9f\xf1\xc9m\xd6N\x8e\U0003dbe6\x88w\x81Z\x92L,\xc9,)f\xe96\xc9\xc4fВb\x96n\x93L,>YR\xcc\xd2m\x92\x11\xe1\xa6=\xda\xeb6{\x87m;\x1b\xb8\xa3\x90\xf7a\x1bRtnZ9\xf3\x82\xaax\xd1$%\xaa\x87)t\x17\x8c\xd9\xd0" +
+			"\xad\x94\x9c\xd4\x04[?\xbf^\x88\xb0\xa8\xf1\xe6\xd8[\xc5\x18*\xa9\x89v\x91\xc6y0\xf3\x90\xf4v;\xd3*\"\xd9T\xbf)Ȟ\xdfH6\xd9o$S\xfdF\xb2\xc9~\xbb\xbaaӽy\xbd\xe8+||\xab\xf0\xdb=/\xee~7+\x9aͶ[\xad\xa6\fNu\xbd\x82\xec\xb9>\x83\x93]\x9fA\xd5\xf5\x19\xbc\xde\xf5\x93[v" +
+			"\x83\xef\xa7˾\xc5\xf9\xd7J\xbf\xdd\xfb\xedE\xc8\x16\x9e\x1b\xf1\xb3\x82\xec\xf9\x19\x9f&\xfb\x19\x9fT?\xe3\xd3\xf5~\xb6\xb4\xe1\x06\x8fڤ\xdc\xe2;\xb7\x9c\xab\xbd\xd4\x1b\xf0\xc5\xd4G\xe5/\x93{\xaf\x1eI\xa4H\x92]#r$>\xa5\xcc\f^#\xb3\xb5\x9b,=0\xe1\xeaf\\e\x95\xe6tl\"\"@\x8e2#A\xae\x83{" +
+			"qn\xc9\x1e\bu\x8eV\xa3\xddV\xbc\x17\xf0:z\xda\xdc\xcb\xd6\xe4\xd1>a\xc0\x8d\u0fe6\xa6\xf1~c-\xf0\x86\xb6\xdd\xd4ä$\x19pñT\x7f\xf8\xe0)\x7f\xbe\"\x14ğ\xbd\xb0BqQA/<\x9e\xf3\xd8\vs\x90!\xef\xa2\x01\xd9\x1f\xf5丷\x8e\xa2\xbd\x1d!\xdf\x1e\xed\xe9/\x8a\xf6\xe47\xdfk_\x19\xdd" +
+			"/߾h\xdf\x13\x8d\xe9#\xd4\xdbU<q\xbdJ\x1f\xa4\xbf\xb4\xdfk\xdf\xda\xdf\x036V\xf2T\x13[*\xe5=\xdb\xe3\x97\xe9h\x99c\xd6L\xb1ӎ\xfcܩxU8\xaa\xfe{X\"\xe4\xddg$\xae\xf8\xab\xb0{\x10m\x99A\x9c\xb1\xbd\xbezGXcD)\xaa\xb8%\xd92\x14[\x16\xdb\x7f0\xa47\x9f\x1e\xef\xf9\xd5H" +
+			"O\x1f\xc4\x17\xbe\x93A|濄w8\x98>{\xfc\x9a\xd3Ow\xed\xe5\x10wO\x9aT\x15Ӯ\xd8\x18\x18\x8eKf\r\xac\x04'\xc4WA\x19\xf0r\xf1\xc2\x7f\"@\x8aܫ\xeb\xc7\xfbdf)Z>=\x12V\xf5I\xa2\xff\xeb\x8c\b\r\x7f@4)\xa0W\xd7\xff\x03e\xa4ܫY\xff\xa8\nZXs\xe4\xbf\xff\xfb\x9f\x7f" +
+			"\xe5\xd5I\xb1\x8f\xf7\xa5\xb5ZV\xf2\xfb\x02\"\xaf\xae\xbd\xc0c\xdf~\xa4\x80\x9e\xc9O\xe8\x95\xf2\xf2\xeeb\x7fF$\xaeR~\x84҆|\xbc\x87\xe9\xf3\xd3\a\xd7Wf\xd4\x14\n\x8b\xa2\x9cލY\xf8r\xf1ң\x17\xfe\x9f\xb4\xc0\x80UI\xbc\xba\ueec9I|n!w\x9d?r\x84=m5\xdf\xe6@\xc5\xd1\xdaB\xf6\xdd\xd3" +
+			"c2\x7f\xea\xaa~\xbcO\xe6OF\x83Z!\xf2l\xa9\x10#\xbe(W\x91Y\xaa\x15\xa5\xba\x10\xb5\xe7W\xeeL)\xe0\xe9\xdfR\x84\xe1\xe3=Mơ\xcc\xe5Ӑ? B\xc0i\x04\xfcx\xef\xd2\xef\xf1~\xa0e\x8fT\xef\x8d\xe6\x9f\xcbūد\x01Cn\xbf\xd2J\xf0\xe91. \xe2\x01\xfc\x0f@\x13\x1e\xb9<\xe5\xf1\x9e\xc2" +
+			"\xf1\xd2J\x7f\x99^@\xdap\xb4\x8cی\xc2\x1a(\x87\x8e\xd6?\xde;L\xf9x/\xf63\x0fuMij\x94\xb3\xa1惭\xd7\xfdȇiW\x87\xe3\x83\xf8\xfb\xf65^\xe1`7\xeb\x95g\xedw\xf5\xae\x027X\xbec\xe6\x9csv\x80wSB\xcf\xdavM:N\x1b\xe9\x92\xc7\xc4e>\xda%)w#\x91\xa24\x87\xcd\xed" +
+			"\xee\x9e\x1eI\t\xf2&\x891\xa2 \x92\x7f;\xe71\xfb&\xc6\xf6\x12\xe4.\x039\xa5\xa7\x18\x19\xd2\x19\xdd\xdf=\xfd\x8d\x93\xfe\xc5\v\xf9\x87N\xbcC\x8f\x14\xa3\xab\xf4x\xbc\xc7魱]`\x87_˧\x9f\x12\xe4\x89I\x04\xad@\x8c\xbc\x94xr\xa6\xee\x1dP\f\xce\x04y\xa2\xc3W\x8cOϯ\xe1\x9f\xd1\xe1|\x92]\x9e\xa1\xd9" +
+			"\xcfl\xb4\x90 Z\x9d\x91\xcc\v\xed\xd4\xd7o\xe4\xf4\xce\xd4v\x98JP\xf4\xfbv\x19\xc9\xfb\xef\xd7i~\xdb\t\xc9\x14Ӿ\x85O\x87\x99\xe5\x91V\x8dHqU\xf9\x9d7>\x94\xff{A\xe8\xf0\x00\xae\x0e\xfaM\xf3Y\xa97\x8e\xfc7i\xfbO\x94\x15\x14y\x7f\x82\xb0B\x84\\\xaf\xb7(ϊ\xbf\x99\xb7\xe4P\xfa/\x9f\xd1\x17\xdf" +
+			"\xfb\x97g\x80\xcf\xc8\xdb}RL\xc4g\xcaof\xf6˅Wq\r5KńJu\xcd\xdaϊ\x1f\xaa\xfb\xa7\xb6\x13\xff\x7f\xc5\xd9ױs\x1346>\xfa)I\x89\xc7\x1eT\xbc\x17@\xbc\x13\xcaQ\x05(\x82\xde\xe1\x8bW\x82\xf83\xcb`c\xe6\xe0\xf0f黏\xf7\xa2Q\x8f\xf7\t\xcd\xf0Ӈ\xff7\x00\x18\x1b1\xdeU" +
+			"\xa9\x00\x00",
 	},
 
 	"/internal/assets/recover.jpg": {
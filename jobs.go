@@ -0,0 +1,449 @@
+package rst
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// JobStatus is the status of a Job.
+type JobStatus string
+
+// Statuses of a Job.
+const (
+	JobPending   JobStatus = "pending"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+	JobCanceled  JobStatus = "canceled"
+)
+
+// Defaults of the mux handling jobs. See HandleJobs.
+const (
+	// DefaultJobRetention is the duration during which the status of a
+	// finished job remains available, unless the JobRetention field of the mux
+	// handling jobs is set.
+	DefaultJobRetention = time.Hour
+
+	// DefaultJobPollInterval is the delay sent in the Retry-After header of the
+	// status of unfinished jobs, unless the JobPollInterval field of the mux
+	// handling jobs is set.
+	DefaultJobPollInterval = time.Second
+
+	// DefaultMaxWait is the maximum delay during which requests starting jobs
+	// wait for them to finish, unless the MaxWait field of the mux handling
+	// jobs is set.
+	DefaultMaxWait = 30 * time.Second
+)
+
+// errNoJobs is returned when a job is returned by an endpoint of a mux that
+// doesn't handle jobs.
+var errNoJobs = errors.New("rst: jobs can only be returned by the endpoints of a mux handling jobs. See Mux.HandleJobs")
+
+// JobFunc runs an asynchronous operation. It returns the location of the
+// resource it produced, if any, or an error. ctx is done when the job is
+// canceled.
+type JobFunc func(ctx context.Context, job *Job) (location string, err error)
+
+/*
+Job is an operation running in the background, created with NewJob. A Job
+returned by a Poster is started, and the client receives a 202 Accepted
+response with a Location header pointing to the status of the job.
+
+	func (ep *ImportsEP) Post(vars rst.RouteVars, r *http.Request) (rst.Resource, string, error) {
+		file, err := ep.upload(r)
+		if err != nil {
+			return nil, "", err
+		}
+		return rst.NewJob(func(ctx context.Context, job *rst.Job) (string, error) {
+			for i, row := range file.Rows {
+				if err := ep.db.Insert(ctx, row); err != nil {
+					return "", err
+				}
+				job.SetProgress(float64(i+1)/float64(len(file.Rows)), "importing")
+			}
+			return "/imports/" + file.ID, nil
+		}), "", nil
+	}
+
+The status of jobs is served by the mux with HandleJobs.
+*/
+type Job struct {
+	id string
+	fn JobFunc
+
+	mu       sync.Mutex
+	status   JobStatus
+	progress float64
+	message  string
+	location string
+	err      error
+	created  time.Time
+	updated  time.Time
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// NewJob returns a new pending Job running fn once started.
+func NewJob(fn JobFunc) *Job {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	now := time.Now()
+	return &Job{
+		id:      hex.EncodeToString(b),
+		fn:      fn,
+		status:  JobPending,
+		created: now,
+		updated: now,
+		done:    make(chan struct{}),
+	}
+}
+
+// ID returns the unique identifier of j.
+func (j *Job) ID() string {
+	return j.id
+}
+
+// Status returns the current status of j.
+func (j *Job) Status() JobStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.status
+}
+
+// Err returns the error returned by a failed job.
+func (j *Job) Err() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.err
+}
+
+// Done returns a channel closed once j has finished.
+func (j *Job) Done() <-chan struct{} {
+	return j.done
+}
+
+// SetProgress reports the progress of j, between 0 and 1, along with a
+// message describing the current step.
+func (j *Job) SetProgress(progress float64, message string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if progress < 0 {
+		progress = 0
+	} else if progress > 1 {
+		progress = 1
+	}
+	j.progress, j.message, j.updated = progress, message, time.Now()
+}
+
+// Cancel cancels j if it hasn't finished yet.
+func (j *Job) Cancel() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.status != JobPending && j.status != JobRunning {
+		return
+	}
+	j.status, j.updated = JobCanceled, time.Now()
+	if j.cancel != nil {
+		j.cancel()
+	} else {
+		close(j.done)
+	}
+}
+
+// TTL implements the rst.Resource interface.
+func (j *Job) TTL() time.Duration {
+	return 0
+}
+
+// LastModified implements the rst.Resource interface.
+func (j *Job) LastModified() time.Time {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.updated
+}
+
+// ETag implements the rst.Resource interface.
+func (j *Job) ETag() string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.id + "-" + strconv.FormatInt(j.updated.UnixNano(), 36)
+}
+
+// start runs j in its own goroutine, unless it was canceled.
+func (j *Job) start() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.status != JobPending {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	j.status, j.updated, j.cancel = JobRunning, time.Now(), cancel
+
+	go func() {
+		defer close(j.done)
+		defer cancel()
+		location, err := j.run(ctx)
+
+		j.mu.Lock()
+		defer j.mu.Unlock()
+		switch {
+		case j.status == JobCanceled:
+		case err != nil:
+			j.status, j.err = JobFailed, err
+		default:
+			j.status, j.location, j.progress = JobSucceeded, location, 1
+		}
+		j.updated = time.Now()
+	}()
+}
+
+// run calls the function of j, and returns the panics it raises as errors.
+func (j *Job) run(ctx context.Context) (location string, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("%v", rec)
+		}
+	}()
+	return j.fn(ctx, j)
+}
+
+// jobState is the representation of the status of a Job.
+type jobState struct {
+	XMLName  xml.Name  `json:"-" xml:"job"`
+	ID       string    `json:"id" xml:"id"`
+	Status   JobStatus `json:"status" xml:"status"`
+	Progress float64   `json:"progress" xml:"progress"`
+	Message  string    `json:"message,omitempty" xml:"message,omitempty"`
+	Error    string    `json:"error,omitempty" xml:"error,omitempty"`
+	Created  time.Time `json:"created" xml:"created"`
+	Updated  time.Time `json:"updated" xml:"updated"`
+}
+
+// resource returns the resource representing the status of j, which redirects
+// to the location of its result once it has succeeded. Clients are asked to
+// poll the status of unfinished jobs again after poll.
+func (j *Job) resource(poll time.Duration) Resource {
+	j.mu.Lock()
+	state := &jobState{
+		ID:       j.id,
+		Status:   j.status,
+		Progress: j.progress,
+		Message:  j.message,
+		Created:  j.created,
+		Updated:  j.updated,
+	}
+	if j.err != nil {
+		state.Error = j.err.Error()
+		if e, ok := j.err.(*Error); ok && e.Description != "" {
+			state.Error = e.Description
+		} else if ok && e.Reason != "" {
+			state.Error = e.Reason
+		}
+	}
+	location := j.location
+	etag := j.id + "-" + strconv.FormatInt(j.updated.UnixNano(), 36)
+	j.mu.Unlock()
+
+	envelope := NewEnvelope(state, state.Updated, etag, 0)
+	switch state.Status {
+	case JobPending, JobRunning:
+		envelope.Header().Set("Retry-After", retryAfter(poll))
+	case JobSucceeded:
+		if location != "" {
+			return &jobResult{envelope, location}
+		}
+	}
+	return envelope
+}
+
+// retryAfter returns the value of a Retry-After header for d, in seconds
+// rounded up, and at least 1.
+func retryAfter(d time.Duration) string {
+	seconds := int64((d + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return strconv.FormatInt(seconds, 10)
+}
+
+// jobResult is the status of a job that produced a resource.
+type jobResult struct {
+	*Envelope
+	location string
+}
+
+// ServeHTTP implements http.Handler by redirecting to the resource produced by
+// the job.
+func (res *jobResult) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Location", resolveLocation(r, res.location))
+	w.WriteHeader(http.StatusSeeOther)
+	w.Write(noContent)
+}
+
+// jobRegistry holds the jobs started by the endpoints of a mux.
+type jobRegistry struct {
+	mux    *Mux   // Mux handling the jobs.
+	prefix string // Prefix of the URL of the status of jobs.
+
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+// add starts j and registers it until the retention delay of the mux has
+// elapsed after it has finished.
+func (reg *jobRegistry) add(j *Job) {
+	reg.mu.Lock()
+	reg.jobs[j.id] = j
+	reg.mu.Unlock()
+
+	j.start()
+	go func() {
+		<-j.Done()
+		time.AfterFunc(reg.retention(), func() {
+			reg.remove(j.id)
+		})
+	}()
+}
+
+// maxWait returns the maximum delay during which requests starting jobs wait
+// for them to finish, in whole seconds.
+func (reg *jobRegistry) maxWait() time.Duration {
+	max := reg.mux.MaxWait
+	if max <= 0 {
+		max = DefaultMaxWait
+	}
+	return max / time.Second * time.Second
+}
+
+// retention returns the duration during which the status of finished jobs
+// remains available.
+func (reg *jobRegistry) retention() time.Duration {
+	if reg.mux.JobRetention > 0 {
+		return reg.mux.JobRetention
+	}
+	return DefaultJobRetention
+}
+
+// pollInterval returns the delay after which clients should poll the status of
+// unfinished jobs again.
+func (reg *jobRegistry) pollInterval() time.Duration {
+	if reg.mux.JobPollInterval > 0 {
+		return reg.mux.JobPollInterval
+	}
+	return DefaultJobPollInterval
+}
+
+// get returns the job identified by id, or nil.
+func (reg *jobRegistry) get(id string) *Job {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	return reg.jobs[id]
+}
+
+// remove unregisters the job identified by id.
+func (reg *jobRegistry) remove(id string) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	delete(reg.jobs, id)
+}
+
+// jobsEndpoint serves the status of the jobs of a registry.
+type jobsEndpoint struct {
+	reg *jobRegistry
+}
+
+func (ep *jobsEndpoint) Get(vars RouteVars, r *http.Request) (Resource, error) {
+	job := ep.reg.get(vars.Get("id"))
+	if job == nil {
+		return nil, NotFound()
+	}
+	return job.resource(ep.reg.pollInterval()), nil
+}
+
+func (ep *jobsEndpoint) Delete(vars RouteVars, r *http.Request) error {
+	job := ep.reg.get(vars.Get("id"))
+	if job == nil {
+		return NotFound()
+	}
+	job.Cancel()
+	ep.reg.remove(job.id)
+	return nil
+}
+
+// writeJob starts job, and writes a 202 Accepted response pointing to its
+// status.
+//
+// Clients preferring to wait for the job to finish receive its status, as if
+// they had requested it, if the job finishes within the delay they asked for.
+func writeJob(job *Job, w http.ResponseWriter, r *http.Request) {
+	reg, _ := r.Context().Value(jobsKey).(*jobRegistry)
+	if reg == nil {
+		writeError(errNoJobs, w, r)
+		return
+	}
+	if _, _, err := Marshal(job.resource(reg.pollInterval()), r); err != nil {
+		writeError(err, w, r)
+		return
+	}
+	reg.add(job)
+	status := resolveLocation(r, reg.prefix+"/"+job.id)
+
+	prefs := RequestPreferences(r)
+	wait := prefs.Wait
+	if max := reg.maxWait(); wait > max {
+		wait = max
+	}
+	if wait > 0 {
+		addPreferenceApplied(w.Header(), "wait="+strconv.FormatInt(int64(wait/time.Second), 10))
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-job.Done():
+			if result, ok := job.resource(reg.pollInterval()).(*jobResult); ok {
+				result.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Set("Content-Location", status)
+			writeJobStatus(job, reg, http.StatusOK, prefs, w, r)
+			return
+		case <-timer.C:
+		case <-r.Context().Done():
+		}
+	}
+	if prefs.RespondAsync {
+		addPreferenceApplied(w.Header(), "respond-async")
+	}
+	w.Header().Set("Location", status)
+	w.Header().Set("Retry-After", retryAfter(reg.pollInterval()))
+	writeJobStatus(job, reg, http.StatusAccepted, prefs, w, r)
+}
+
+// writeJobStatus writes the status of job in the response to the request that
+// started it, unless the client prefers a minimal response.
+func writeJobStatus(job *Job, reg *jobRegistry, code int, prefs *Preferences, w http.ResponseWriter, r *http.Request) {
+	addVary(w.Header(), "Accept")
+	if prefs.Return == "minimal" {
+		addPreferenceApplied(w.Header(), "return=minimal")
+		w.WriteHeader(code)
+		w.Write(noContent)
+		return
+	}
+	contentType, b, err := Marshal(job.resource(reg.pollInterval()), r)
+	if err != nil {
+		writeError(err, w, r)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(code)
+	w.Write(b)
+}
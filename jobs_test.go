@@ -0,0 +1,246 @@
+package rst
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newJobsMux(fn JobFunc) *Mux {
+	mux := NewMux()
+	mux.HandleJobs("/jobs")
+	mux.Group("/v1").Post("/imports", func(vars RouteVars, r *http.Request) (Resource, string, error) {
+		return NewJob(fn), "", nil
+	})
+	return mux
+}
+
+func serveJobs(mux *Mux, method, url string) *httptest.ResponseRecorder {
+	r, _ := http.NewRequest(method, url, nil)
+	r.Header.Set("Accept", "application/json")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	return w
+}
+
+func waitJob(t *testing.T, mux *Mux, id string) {
+	job := mux.jobs.get(id)
+	if job == nil {
+		t.Fatal("job", id, "not found")
+	}
+	select {
+	case <-job.Done():
+	case <-time.After(time.Second):
+		t.Fatal("job", id, "not done")
+	}
+}
+
+func TestJob(t *testing.T) {
+	progress := make(chan struct{})
+	resume := make(chan struct{})
+	mux := newJobsMux(func(ctx context.Context, job *Job) (string, error) {
+		job.SetProgress(0.5, "halfway")
+		progress <- struct{}{}
+		<-resume
+		return "/imports/42", nil
+	})
+
+	w := serveJobs(mux, Post, "http://www.example.com/v1/imports")
+	if w.Code != http.StatusAccepted {
+		t.Fatal("Got:", w.Code, "Wanted:", http.StatusAccepted)
+	}
+	var state jobState
+	if err := json.Unmarshal(w.Body.Bytes(), &state); err != nil {
+		t.Fatal(err)
+	}
+	if location := w.Header().Get("Location"); location != "http://www.example.com/jobs/"+state.ID {
+		t.Fatal("Got:", location, "Wanted:", "http://www.example.com/jobs/"+state.ID)
+	}
+
+	<-progress
+	w = serveJobs(mux, Get, "http://www.example.com/jobs/"+state.ID)
+	if w.Code != http.StatusOK || w.Header().Get("Retry-After") != "1" {
+		t.Fatal("Got:", w.Code, w.Header(), "Wanted:", http.StatusOK)
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &state); err != nil {
+		t.Fatal(err)
+	}
+	if state.Status != JobRunning || state.Progress != 0.5 || state.Message != "halfway" {
+		t.Fatal("Got:", state)
+	}
+
+	close(resume)
+	waitJob(t, mux, state.ID)
+	w = serveJobs(mux, Get, "http://www.example.com/jobs/"+state.ID)
+	if w.Code != http.StatusSeeOther || w.Header().Get("Location") != "http://www.example.com/imports/42" {
+		t.Fatal("Got:", w.Code, w.Header(), "Wanted:", http.StatusSeeOther)
+	}
+
+	if w = serveJobs(mux, Delete, "http://www.example.com/jobs/"+state.ID); w.Code != http.StatusNoContent {
+		t.Fatal("Got:", w.Code, "Wanted:", http.StatusNoContent)
+	}
+	if w = serveJobs(mux, Get, "http://www.example.com/jobs/"+state.ID); w.Code != http.StatusNotFound {
+		t.Fatal("Got:", w.Code, "Wanted:", http.StatusNotFound)
+	}
+}
+
+func TestJobFailure(t *testing.T) {
+	var test = func(fn JobFunc, expected string) {
+		mux := newJobsMux(fn)
+		var state jobState
+		w := serveJobs(mux, Post, "http://www.example.com/v1/imports")
+		if err := json.Unmarshal(w.Body.Bytes(), &state); err != nil {
+			t.Fatal(err)
+		}
+		waitJob(t, mux, state.ID)
+		w = serveJobs(mux, Get, "http://www.example.com/jobs/"+state.ID)
+		if err := json.Unmarshal(w.Body.Bytes(), &state); err != nil {
+			t.Fatal(err)
+		}
+		if w.Code != http.StatusOK || state.Status != JobFailed || state.Error != expected {
+			t.Fatal("Got:", w.Code, state, "Wanted:", expected)
+		}
+	}
+	test(func(ctx context.Context, job *Job) (string, error) {
+		return "", errors.New("import failed")
+	}, "import failed")
+	test(func(ctx context.Context, job *Job) (string, error) {
+		panic("import panicked")
+	}, "import panicked")
+	test(func(ctx context.Context, job *Job) (string, error) {
+		return "", NewError(http.StatusConflict, "Import conflicted", "")
+	}, "Import conflicted")
+}
+
+func TestJobSettings(t *testing.T) {
+	resume := make(chan struct{})
+	mux := newJobsMux(func(ctx context.Context, job *Job) (string, error) {
+		<-resume
+		return "", nil
+	})
+	mux.JobPollInterval = 1500 * time.Millisecond
+	mux.JobRetention = 10 * time.Millisecond
+
+	var state jobState
+	w := serveJobs(mux, Post, "http://www.example.com/v1/imports")
+	if err := json.Unmarshal(w.Body.Bytes(), &state); err != nil {
+		t.Fatal(err)
+	}
+	if retry := w.Header().Get("Retry-After"); retry != "2" {
+		t.Fatal("Got:", retry, "Wanted:", "2")
+	}
+	mux.JobPollInterval = time.Millisecond
+	if w = serveJobs(mux, Get, "http://www.example.com/jobs/"+state.ID); w.Header().Get("Retry-After") != "1" {
+		t.Fatal("Got:", w.Header().Get("Retry-After"), "Wanted:", "1")
+	}
+
+	close(resume)
+	waitJob(t, mux, state.ID)
+	time.Sleep(50 * time.Millisecond)
+	if w = serveJobs(mux, Get, "http://www.example.com/jobs/"+state.ID); w.Code != http.StatusNotFound {
+		t.Fatal("Got:", w.Code, "Wanted:", http.StatusNotFound)
+	}
+}
+
+func TestJobCancel(t *testing.T) {
+	started := make(chan struct{})
+	mux := newJobsMux(func(ctx context.Context, job *Job) (string, error) {
+		close(started)
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+
+	var state jobState
+	w := serveJobs(mux, Post, "http://www.example.com/v1/imports")
+	if err := json.Unmarshal(w.Body.Bytes(), &state); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	job := mux.jobs.get(state.ID)
+	if w = serveJobs(mux, Delete, "http://www.example.com/jobs/"+state.ID); w.Code != http.StatusNoContent {
+		t.Fatal("Got:", w.Code, "Wanted:", http.StatusNoContent)
+	}
+	select {
+	case <-job.Done():
+	case <-time.After(time.Second):
+		t.Fatal("canceled job not done")
+	}
+	if job.Status() != JobCanceled {
+		t.Fatal("Got:", job.Status(), "Wanted:", JobCanceled)
+	}
+
+	// Jobs canceled before they're started never run.
+	job = NewJob(func(ctx context.Context, job *Job) (string, error) {
+		t.Fatal("canceled job started")
+		return "", nil
+	})
+	job.Cancel()
+	job.start()
+	<-job.Done()
+}
+
+func TestJobWithoutRegistry(t *testing.T) {
+	mux := NewMux()
+	mux.Post("/imports", func(vars RouteVars, r *http.Request) (Resource, string, error) {
+		return NewJob(func(ctx context.Context, job *Job) (string, error) {
+			return "", nil
+		}), "", nil
+	})
+	if w := serveJobs(mux, Post, "http://www.example.com/imports"); w.Code != http.StatusInternalServerError {
+		t.Fatal("Got:", w.Code, "Wanted:", http.StatusInternalServerError)
+	}
+}
+
+func TestJobPreferences(t *testing.T) {
+	resume := make(chan struct{})
+	mux := newJobsMux(func(ctx context.Context, job *Job) (string, error) {
+		<-resume
+		return "/imports/42", nil
+	})
+	var post = func(prefer string) *httptest.ResponseRecorder {
+		r, _ := http.NewRequest(Post, "http://www.example.com/v1/imports", nil)
+		r.Header.Set("Accept", "application/json")
+		r.Header.Set("Prefer", prefer)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w
+	}
+
+	// The job finishes within the delay of the client.
+	go func() {
+		resume <- struct{}{}
+	}()
+	w := post("wait=5")
+	if w.Code != http.StatusSeeOther || w.Header().Get("Location") != "http://www.example.com/imports/42" {
+		t.Fatal("Got:", w.Code, w.Header(), "Wanted:", http.StatusSeeOther)
+	}
+	if w.Header().Get("Preference-Applied") != "wait=5" {
+		t.Fatal("Got:", w.Header(), "Wanted: a Preference-Applied header")
+	}
+
+	// The job is still running when the client stops waiting.
+	w = post("respond-async, wait=1, return=minimal")
+	if w.Code != http.StatusAccepted || w.Body.Len() != 0 || w.Header().Get("Location") == "" {
+		t.Fatal("Got:", w.Code, w.Header(), w.Body.String(), "Wanted:", http.StatusAccepted)
+	}
+	if applied := strings.Join(w.Header()["Preference-Applied"], ", "); applied != "wait=1, respond-async, return=minimal" {
+		t.Fatal("Got:", applied, "Wanted:", "wait=1, respond-async, return=minimal")
+	}
+
+	// The delay is capped by the mux.
+	mux.MaxWait = time.Second
+	start := time.Now()
+	w = post("wait=3600")
+	if w.Code != http.StatusAccepted || w.Header().Get("Preference-Applied") != "wait=1" {
+		t.Fatal("Got:", w.Code, w.Header(), "Wanted:", http.StatusAccepted)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatal("Got:", elapsed, "Wanted: less than", 2*time.Second)
+	}
+	close(resume)
+}
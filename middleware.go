@@ -0,0 +1,40 @@
+package rst
+
+import (
+	"net/http"
+)
+
+/*
+Middleware is a function run on requests once they have been matched with a
+route of a Mux, and before the handler of the route is called.
+
+The matched route and the variables extracted from the URL are passed along
+with the request. A middleware can call next to continue the chain, or return an
+error to interrupt it without calling next. The error will be written in the
+response, which means an error should never be returned once next was called.
+
+	func auth(route *rst.Route, vars rst.RouteVars, w http.ResponseWriter, r *http.Request, next http.Handler) error {
+		if r.Header.Get("Authorization") == "" {
+			return rst.Unauthorized()
+		}
+		next.ServeHTTP(w, r)
+		return nil
+	}
+
+	mux.Use(auth)
+
+Middleware can also be attached to a single route:
+
+	mux.HandleEndpoint("/people/{id:\\d+}", &PersonEP{}).Use(audit)
+*/
+type Middleware func(route *Route, vars RouteVars, w http.ResponseWriter, r *http.Request, next http.Handler) error
+
+// wrap returns a handler calling mw with the given route and vars, and next as
+// the following handler in the chain.
+func (mw Middleware) wrap(route *Route, vars RouteVars, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := mw(route, vars, w, r, next); err != nil {
+			writeError(err, w, r)
+		}
+	})
+}
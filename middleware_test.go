@@ -0,0 +1,60 @@
+package rst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	var calls []string
+	var trace = func(name string) Middleware {
+		return func(route *Route, vars RouteVars, w http.ResponseWriter, r *http.Request, next http.Handler) error {
+			calls = append(calls, name+" "+route.Pattern()+" "+vars.Get("id"))
+			next.ServeHTTP(w, r)
+			return nil
+		}
+	}
+	var auth = func(route *Route, vars RouteVars, w http.ResponseWriter, r *http.Request, next http.Handler) error {
+		if _, ok := route.Endpoint().(*personResource); !ok {
+			t.Errorf("unexpected endpoint %T", route.Endpoint())
+		}
+		if r.Header.Get("Authorization") == "" {
+			return Unauthorized()
+		}
+		next.ServeHTTP(w, r)
+		return nil
+	}
+
+	mux := NewMux()
+	mux.Use(trace("mux"))
+	mux.HandleEndpoint("/people/{id}", &personResource{}).Use(trace("route"), auth)
+	mux.Get("/employers", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return nil, nil
+	})
+
+	var test = func(url string, header http.Header, code int, expected ...string) {
+		calls = nil
+		r, _ := http.NewRequest(Get, url, nil)
+		if header != nil {
+			r.Header = header
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		if w.Code != code {
+			t.Fatal("Got:", w.Code, "Wanted:", code)
+		}
+		if strings.Join(calls, ", ") != strings.Join(expected, ", ") {
+			t.Fatal("Got:", calls, "Wanted:", expected)
+		}
+	}
+
+	id := testPeople[2].ID
+	test("http://www.example.com/people/"+id, nil, http.StatusUnauthorized, "mux /people/{id} "+id, "route /people/{id} "+id)
+	test("http://www.example.com/people/"+id, http.Header{"Authorization": []string{"token"}}, http.StatusOK, "mux /people/{id} "+id, "route /people/{id} "+id)
+	test("http://www.example.com/employers", nil, http.StatusNoContent, "mux /employers ")
+
+	// Middleware only run after routing.
+	test("http://www.example.com/unknown", nil, http.StatusNotFound)
+}
@@ -0,0 +1,359 @@
+/*
+Package openapi generates OpenAPI 3 documents describing the routes of an
+rst.Mux.
+
+Paths, path parameters, methods, media types and error responses are derived
+from the routes registered in the mux. Endpoints can complete the description of
+their operations by implementing the Describer interface.
+
+	doc := openapi.Generate(mux, openapi.Info{Title: "People", Version: "1.0"})
+	b, err := json.Marshal(doc)
+*/
+package openapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mohamedattahri/rst"
+)
+
+// Version of the OpenAPI specification implemented by the documents generated
+// by this package.
+const Version = "3.0.3"
+
+// Document is the root object of an OpenAPI document.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []*Server            `json:"servers,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components *Components          `json:"components,omitempty"`
+}
+
+// Info provides metadata about the API.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Server is a server hosting the API.
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem describes the operations available on a single path, keyed by
+// lowercase HTTP method.
+type PathItem map[string]*Operation
+
+// Operation describes a single API operation on a path.
+type Operation struct {
+	OperationID string               `json:"operationId,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter describes a single operation parameter.
+type Parameter struct {
+	Name        string `json:"name"`
+	In          string `json:"in"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Schema      Schema `json:"schema,omitempty"`
+}
+
+// RequestBody describes the entity expected in a request.
+type RequestBody struct {
+	Description string                `json:"description,omitempty"`
+	Required    bool                  `json:"required,omitempty"`
+	Content     map[string]*MediaType `json:"content"`
+}
+
+// Response describes a single response of an operation.
+type Response struct {
+	Description string                `json:"description"`
+	Headers     map[string]*Header    `json:"headers,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// Header describes a header of a response.
+type Header struct {
+	Description string `json:"description,omitempty"`
+	Schema      Schema `json:"schema,omitempty"`
+}
+
+// MediaType describes the content of a request or a response in a given
+// format.
+type MediaType struct {
+	Schema Schema `json:"schema,omitempty"`
+}
+
+// Components holds reusable objects referenced by the document.
+type Components struct {
+	Schemas map[string]Schema `json:"schemas,omitempty"`
+}
+
+// Schema is a JSON schema, as understood by OpenAPI.
+type Schema map[string]interface{}
+
+// Ref returns a schema referencing the schema registered in the components of
+// the document under name.
+func Ref(name string) Schema {
+	return Schema{"$ref": "#/components/schemas/" + name}
+}
+
+/*
+Describer is implemented by endpoints that complete the description of their
+operations, typically to add the schemas of their resources, or the 206
+Partial Content response of the endpoints serving Rangers.
+
+	func (ep *PersonEP) Describe(method string, op *openapi.Operation) {
+		op.Tags = []string{"people"}
+		if method == rst.Get {
+			op.Responses["200"].SetSchema(openapi.Ref("Person"))
+		}
+	}
+*/
+type Describer interface {
+	Describe(method string, op *Operation)
+}
+
+// SetSchema sets schema as the schema of all the media types of r.
+func (r *Response) SetSchema(schema Schema) {
+	for _, mt := range r.Content {
+		mt.Schema = schema
+	}
+}
+
+// SetSchema sets schema as the schema of all the media types of b.
+func (b *RequestBody) SetSchema(schema Schema) {
+	for _, mt := range b.Content {
+		mt.Schema = schema
+	}
+}
+
+// problemSchema is the schema of RFC 7807 problem details returned by rst in
+// errors.
+var problemSchema = Schema{
+	"type": "object",
+	"properties": map[string]Schema{
+		"type":     {"type": "string", "format": "uri-reference"},
+		"title":    {"type": "string"},
+		"status":   {"type": "integer"},
+		"detail":   {"type": "string"},
+		"instance": {"type": "string", "format": "uri-reference"},
+		"violations": {
+			"type": "array",
+			"items": Schema{
+				"type": "object",
+				"properties": map[string]Schema{
+					"path":    {"type": "string"},
+					"code":    {"type": "string"},
+					"message": {"type": "string"},
+				},
+			},
+		},
+	},
+}
+
+// Generate returns a document describing the routes of mux. Routes that were
+// not registered with an rst.Endpoint are ignored.
+func Generate(mux *rst.Mux, info Info) *Document {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]*PathItem),
+		Components: &Components{
+			Schemas: map[string]Schema{"Problem": problemSchema},
+		},
+	}
+
+	for _, route := range mux.Routes() {
+		methods := route.Methods()
+		if len(methods) == 0 {
+			continue
+		}
+
+		path, params := parsePattern(route.Pattern())
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+
+		for _, method := range methods {
+			if method == rst.Head || method == rst.Options {
+				continue
+			}
+			op := newOperation(route, method, params)
+			if describer, ok := route.Endpoint().(Describer); ok {
+				describer.Describe(method, op)
+			}
+			(*item)[strings.ToLower(method)] = op
+		}
+	}
+	return doc
+}
+
+// newOperation returns the default description of method on route. params
+// are copied, so that describing an operation doesn't alter the others.
+func newOperation(route *rst.Route, method string, params []*Parameter) *Operation {
+	op := &Operation{
+		Parameters: make([]*Parameter, len(params)),
+		Responses:  make(map[string]*Response),
+	}
+	for i, param := range params {
+		p := *param
+		p.Schema = make(Schema, len(param.Schema))
+		for k, v := range param.Schema {
+			p.Schema[k] = v
+		}
+		op.Parameters[i] = &p
+	}
+	if name := route.GetName(); name != "" {
+		op.OperationID = name + "." + strings.ToLower(method)
+	}
+
+	codecs := route.Codecs()
+	switch method {
+	case rst.Get:
+		op.Responses["200"] = newResponse(http.StatusOK, codecs.Encodable())
+		op.Responses["304"] = newResponse(http.StatusNotModified, nil)
+	case rst.Post:
+		op.Responses["201"] = newResponse(http.StatusCreated, codecs.Encodable())
+		op.Responses["201"].Headers = map[string]*Header{
+			"Location": {
+				Description: "URI of the resource created.",
+				Schema:      Schema{"type": "string", "format": "uri"},
+			},
+		}
+	case rst.Put, rst.Patch:
+		op.Responses["200"] = newResponse(http.StatusOK, codecs.Encodable())
+	case rst.Delete:
+		op.Responses["204"] = newResponse(http.StatusNoContent, nil)
+	}
+
+	switch method {
+	case rst.Post, rst.Put, rst.Patch:
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  newContent(codecs.Decodable()),
+		}
+		addErrors(op, http.StatusBadRequest, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity)
+	}
+	if len(params) > 0 {
+		addErrors(op, http.StatusNotFound)
+	}
+	addErrors(op, http.StatusMethodNotAllowed, http.StatusNotAcceptable)
+	op.Responses["default"] = &Response{
+		Description: "Error",
+		Content:     newProblemContent(),
+	}
+	return op
+}
+
+// addErrors adds the responses of the errors identified by codes to op.
+func addErrors(op *Operation, codes ...int) {
+	for _, code := range codes {
+		op.Responses[strconv.Itoa(code)] = &Response{
+			Description: http.StatusText(code),
+			Content:     newProblemContent(),
+		}
+	}
+}
+
+// newResponse returns a response with the given status code, and content in
+// the given media types.
+func newResponse(code int, mediaTypes []string) *Response {
+	return &Response{
+		Description: http.StatusText(code),
+		Content:     newContent(mediaTypes),
+	}
+}
+
+// newContent returns a map of empty media types descriptions.
+func newContent(mediaTypes []string) map[string]*MediaType {
+	if len(mediaTypes) == 0 {
+		return nil
+	}
+	content := make(map[string]*MediaType)
+	for _, mt := range mediaTypes {
+		content[mt] = &MediaType{}
+	}
+	return content
+}
+
+// newProblemContent returns the content of error responses.
+func newProblemContent() map[string]*MediaType {
+	return map[string]*MediaType{
+		"application/problem+json": {Schema: Ref("Problem")},
+		"application/problem+xml":  {Schema: Ref("Problem")},
+	}
+}
+
+// parsePattern converts a pattern of the router into an OpenAPI path, and
+// returns the parameters it declares.
+//
+//	/people/{id:\d+} => /people/{id}
+func parsePattern(pattern string) (string, []*Parameter) {
+	var path []byte
+	var params []*Parameter
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '{' {
+			path = append(path, pattern[i])
+			continue
+		}
+
+		// Find the matching brace, as regular expressions may contain some.
+		depth, end := 0, -1
+		for j := i; j < len(pattern) && end < 0; j++ {
+			switch pattern[j] {
+			case '{':
+				depth++
+			case '}':
+				if depth--; depth == 0 {
+					end = j
+				}
+			}
+		}
+		if end < 0 {
+			path = append(path, pattern[i:]...)
+			break
+		}
+
+		name, expr := pattern[i+1:end], ""
+		if k := strings.Index(name, ":"); k >= 0 {
+			name, expr = name[:k], name[k+1:]
+		}
+		params = append(params, &Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   paramSchema(expr),
+		})
+		path = append(path, '{')
+		path = append(path, name...)
+		path = append(path, '}')
+		i = end
+	}
+	return string(path), params
+}
+
+// paramSchema returns the schema of a path parameter matching the regular
+// expression expr.
+func paramSchema(expr string) Schema {
+	switch expr {
+	case "":
+		return Schema{"type": "string"}
+	case `\d+`, `[0-9]+`:
+		return Schema{"type": "integer"}
+	}
+	return Schema{"type": "string", "pattern": "^" + expr + "$"}
+}
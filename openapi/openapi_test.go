@@ -0,0 +1,114 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/mohamedattahri/rst"
+)
+
+type personEP struct{}
+
+func (ep *personEP) Get(vars rst.RouteVars, r *http.Request) (rst.Resource, error) {
+	return nil, nil
+}
+
+func (ep *personEP) Delete(vars rst.RouteVars, r *http.Request) error {
+	return nil
+}
+
+func (ep *personEP) Describe(method string, op *Operation) {
+	op.Tags = []string{"people"}
+	if method == rst.Get {
+		op.Responses["200"].SetSchema(Ref("Person"))
+		op.Parameters[0].Description = "Identifier of the person."
+		op.Parameters[0].Schema["minimum"] = 1
+	}
+}
+
+func TestParsePattern(t *testing.T) {
+	var test = func(pattern, expected string, schemas ...Schema) {
+		path, params := parsePattern(pattern)
+		if path != expected {
+			t.Fatal(pattern, "Got:", path, "Wanted:", expected)
+		}
+		if len(params) != len(schemas) {
+			t.Fatal(pattern, "Got:", len(params), "parameters. Wanted:", len(schemas))
+		}
+		for i, param := range params {
+			if param.In != "path" || !param.Required {
+				t.Fatal(pattern, "invalid parameter:", param)
+			}
+			if !reflect.DeepEqual(param.Schema, schemas[i]) {
+				t.Fatal(pattern, "Got:", param.Schema, "Wanted:", schemas[i])
+			}
+		}
+	}
+	test("/people", "/people")
+	test("/people/{id}", "/people/{id}", Schema{"type": "string"})
+	test(`/people/{id:\d+}`, "/people/{id}", Schema{"type": "integer"})
+	test(`/codes/{code:[A-Z]{2}}/{n:\d+}`, "/codes/{code}/{n}",
+		Schema{"type": "string", "pattern": "^[A-Z]{2}$"},
+		Schema{"type": "integer"},
+	)
+}
+
+func TestGenerate(t *testing.T) {
+	mux := rst.NewMux()
+	mux.HandleEndpoint(`/people/{id:\d+}`, &personEP{}).Name("person")
+	mux.Post("/people", func(vars rst.RouteVars, r *http.Request) (rst.Resource, string, error) {
+		return nil, "", nil
+	})
+	mux.Handle("/raw", http.NotFoundHandler())
+	mux.Group("/v2").Patch("/people/{id}", func(vars rst.RouteVars, r *http.Request) (rst.Resource, error) {
+		return nil, nil
+	})
+
+	doc := Generate(mux, Info{Title: "People", Version: "1.0"})
+	if doc.OpenAPI != Version || doc.Info.Title != "People" {
+		t.Fatal("unexpected document:", doc)
+	}
+	if _, err := json.Marshal(doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Paths) != 3 {
+		t.Fatal("Got:", len(doc.Paths), "paths. Wanted: 3")
+	}
+
+	person := *doc.Paths["/people/{id}"]
+	if len(person) != 2 || person["get"] == nil || person["delete"] == nil {
+		t.Fatal("unexpected operations:", person)
+	}
+	get := person["get"]
+	if get.OperationID != "person.get" || get.Tags[0] != "people" {
+		t.Fatal("endpoint description was not applied:", get)
+	}
+	if get.Parameters[0].Name != "id" || get.Responses["404"] == nil || get.Responses["default"] == nil || get.Responses["206"] != nil {
+		t.Fatal("unexpected operation:", get)
+	}
+	if param := person["delete"].Parameters[0]; param.Description != "" || param.Schema["minimum"] != nil {
+		t.Fatal("description of get applied to delete:", param)
+	}
+	for _, mt := range rst.DefaultCodecs.Encodable() {
+		if content := get.Responses["200"].Content[mt]; content == nil || content.Schema["$ref"] != "#/components/schemas/Person" {
+			t.Fatal("missing schema for", mt)
+		}
+	}
+
+	post := (*doc.Paths["/people"])["post"]
+	if post == nil || post.RequestBody == nil || post.Responses["201"].Headers["Location"] == nil {
+		t.Fatal("unexpected operation:", post)
+	}
+	if post.RequestBody.Content["application/x-www-form-urlencoded"] == nil {
+		t.Fatal("decodable media types missing from request body")
+	}
+	if post.Responses["422"] == nil || post.Responses["404"] != nil {
+		t.Fatal("unexpected error responses:", post.Responses)
+	}
+
+	if patch := (*doc.Paths["/v2/people/{id}"])["patch"]; patch == nil {
+		t.Fatal("missing operation in group")
+	}
+}
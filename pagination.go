@@ -0,0 +1,252 @@
+package rst
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Link is a link to a related resource, as written in a Link header defined
+// in RFC 8288.
+type Link struct {
+	URL    string
+	Rel    string
+	Params map[string]string // Other target attributes, such as "range".
+}
+
+func (l *Link) String() string {
+	s := fmt.Sprintf("<%s>; rel=%q", l.URL, l.Rel)
+
+	keys := make([]string, 0, len(l.Params))
+	for k := range l.Params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		s += fmt.Sprintf("; %s=%q", k, l.Params[k])
+	}
+	return s
+}
+
+// Links is the list of links written in a Link header.
+type Links []*Link
+
+func (links Links) String() string {
+	values := make([]string, len(links))
+	for i, l := range links {
+		values[i] = l.String()
+	}
+	return strings.Join(values, ", ")
+}
+
+// Get returns the link with the relation type rel, or nil if there isn't one.
+func (links Links) Get(rel string) *Link {
+	for _, l := range links {
+		if l.Rel == rel {
+			return l
+		}
+	}
+	return nil
+}
+
+/*
+Linker is implemented by resources that link to other resources. The links are
+written in the Link header of the responses serving them.
+
+	func (c *Collection) Links(r *http.Request) rst.Links {
+		p := &rst.Paginator{PageSize: 20}
+		return p.PageLinks(r, p.Page(r), c.Count())
+	}
+*/
+type Linker interface {
+	Links(*http.Request) Links
+}
+
+// writeLinks adds the links of resource to the Link header of w.
+func writeLinks(resource Resource, w http.ResponseWriter, r *http.Request) {
+	if linker, ok := resource.(Linker); ok {
+		if links := linker.Links(r); len(links) > 0 {
+			w.Header().Add("Link", links.String())
+		}
+	}
+}
+
+/*
+Paginator computes the links to the first, previous, next and last pages of a
+collection.
+
+Pages can be selected with a Range header, with a page number, or with an
+opaque cursor in the query string of the request:
+
+	GET /people			Range: items=20-39
+	GET /people?page=2
+	GET /people?cursor=dXNlcjoyMA
+
+The links of range-based pages point to the same URL, and carry the value of
+the Range header to send in a "range" attribute. rst adds them for you to the
+partial responses of resources implementing Ranger in any unit other than
+bytes when the PaginateRanges field of the mux is set.
+*/
+type Paginator struct {
+	PageSize    uint64 // Number of items per page. Defaults to the length of the current range, or DefaultPageSize.
+	PageParam   string // Name of the query parameter of page numbers. "page" if empty.
+	CursorParam string // Name of the query parameter of cursors. "cursor" if empty.
+}
+
+// DefaultPageSize is the number of items per page of a Paginator without a
+// PageSize, when the length of the current range is unknown.
+const DefaultPageSize = 20
+
+// pageSize returns the number of items per page, given the length of the
+// current range, which is 0 if unknown.
+func (p *Paginator) pageSize(current uint64) uint64 {
+	switch {
+	case p.PageSize > 0:
+		return p.PageSize
+	case current > 0:
+		return current
+	}
+	return DefaultPageSize
+}
+
+// requestedRangeLength returns the length of the single range requested in the
+// Range header of r, or 0.
+func requestedRangeLength(r *http.Request) uint64 {
+	ranges, err := ParseRange(r.Header.Get("Range"))
+	if err != nil || len(ranges) != 1 || ranges[0].Suffix || ranges[0].To == math.MaxUint64 {
+		return 0
+	}
+	return ranges[0].To - ranges[0].From + 1
+}
+
+func (p *Paginator) pageParam() string {
+	if p.PageParam == "" {
+		return "page"
+	}
+	return p.PageParam
+}
+
+func (p *Paginator) cursorParam() string {
+	if p.CursorParam == "" {
+		return "cursor"
+	}
+	return p.CursorParam
+}
+
+// Page returns the number of the page requested in r, starting at 1. It
+// returns 1 if the parameter is missing or invalid.
+func (p *Paginator) Page(r *http.Request) uint64 {
+	page, err := strconv.ParseUint(r.URL.Query().Get(p.pageParam()), 10, 64)
+	if err != nil || page == 0 {
+		return 1
+	}
+	return page
+}
+
+// PageRange returns the range of the items of page, in unit. Pages start at 1.
+func (p *Paginator) PageRange(unit string, page uint64) *Range {
+	if page == 0 {
+		page = 1
+	}
+	size := p.pageSize(0)
+	from := (page - 1) * size
+	return &Range{Unit: unit, From: from, To: from + size - 1}
+}
+
+// Cursor returns the opaque cursor requested in r, or an empty string.
+func (p *Paginator) Cursor(r *http.Request) string {
+	return r.URL.Query().Get(p.cursorParam())
+}
+
+// queryLink returns a link to the URL of r, with the query parameter key set
+// to value, or removed if value is empty.
+func queryLink(r *http.Request, rel, key, value string) *Link {
+	u := requestURL(r)
+	query := u.Query()
+	if value == "" {
+		query.Del(key)
+	} else {
+		query.Set(key, value)
+	}
+	u.RawQuery = query.Encode()
+	return &Link{URL: u.String(), Rel: rel}
+}
+
+// rangeLink returns a link to the URL of r, with the range of items from-to
+// in a "range" attribute.
+func rangeLink(r *http.Request, rel, unit string, from, to uint64) *Link {
+	return &Link{
+		URL:    requestURL(r).String(),
+		Rel:    rel,
+		Params: map[string]string{"range": fmt.Sprintf("%s=%d-%d", unit, from, to)},
+	}
+}
+
+// RangeLinks returns the links to the pages around cr, the range served in
+// response to r. There is no link to the last page when cr.Total is 0, which
+// means the number of items is unknown.
+func (p *Paginator) RangeLinks(r *http.Request, cr *ContentRange) Links {
+	size := p.pageSize(cr.To - cr.From + 1)
+
+	links := Links{rangeLink(r, "first", cr.Unit, 0, size-1)}
+	if cr.From > 0 {
+		from := uint64(0)
+		if cr.From > size {
+			from = cr.From - size
+		}
+		links = append(links, rangeLink(r, "prev", cr.Unit, from, cr.From-1))
+	}
+	if cr.Total == 0 || cr.To+1 < cr.Total {
+		links = append(links, rangeLink(r, "next", cr.Unit, cr.To+1, cr.To+size))
+	}
+	if cr.Total > 0 {
+		from := (cr.Total - 1) / size * size
+		links = append(links, rangeLink(r, "last", cr.Unit, from, cr.Total-1))
+	}
+	return links
+}
+
+// UnknownCount is the number of items passed to PageLinks when the size of a
+// collection is unknown.
+const UnknownCount = ^uint64(0)
+
+// PageLinks returns the links to the pages around page, in a collection of
+// count items. There is no link to the last page when count is UnknownCount,
+// and the link to the next page is always present.
+func (p *Paginator) PageLinks(r *http.Request, page, count uint64) Links {
+	param := p.pageParam()
+	size := p.pageSize(requestedRangeLength(r))
+	links := Links{queryLink(r, "first", param, "1")}
+	if page > 1 {
+		links = append(links, queryLink(r, "prev", param, strconv.FormatUint(page-1, 10)))
+	}
+	if count == UnknownCount || page*size < count {
+		links = append(links, queryLink(r, "next", param, strconv.FormatUint(page+1, 10)))
+	}
+	if count != UnknownCount {
+		last := (count + size - 1) / size
+		if last == 0 {
+			last = 1
+		}
+		links = append(links, queryLink(r, "last", param, strconv.FormatUint(last, 10)))
+	}
+	return links
+}
+
+// CursorLinks returns the links to the first page, and to the pages identified
+// by the opaque cursors prev and next when they're not empty. Collections
+// paginated with cursors have no link to their last page.
+func (p *Paginator) CursorLinks(r *http.Request, prev, next string) Links {
+	param := p.cursorParam()
+	links := Links{queryLink(r, "first", param, "")}
+	if prev != "" {
+		links = append(links, queryLink(r, "prev", param, prev))
+	}
+	if next != "" {
+		links = append(links, queryLink(r, "next", param, next))
+	}
+	return links
+}
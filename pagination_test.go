@@ -0,0 +1,174 @@
+package rst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLinkString(t *testing.T) {
+	links := Links{
+		{URL: "http://www.example.com/people?page=2", Rel: "next"},
+		{URL: "http://www.example.com/people", Rel: "first", Params: map[string]string{"range": "items=0-9", "title": "First"}},
+	}
+	expected := `<http://www.example.com/people?page=2>; rel="next", <http://www.example.com/people>; rel="first"; range="items=0-9"; title="First"`
+	if got := links.String(); got != expected {
+		t.Fatal("Got:", got, "Wanted:", expected)
+	}
+	if links.Get("first") != links[1] || links.Get("last") != nil {
+		t.Fatal("unexpected links returned by Get")
+	}
+}
+
+func TestPaginatorRangeLinks(t *testing.T) {
+	r, _ := http.NewRequest(Get, "http://www.example.com/people?sort=name", nil)
+	var test = func(cr *ContentRange, expected map[string]string) {
+		links := (&Paginator{}).RangeLinks(r, cr)
+		if len(links) != len(expected) {
+			t.Fatal(cr, "Got:", links, "Wanted:", expected)
+		}
+		for rel, rg := range expected {
+			l := links.Get(rel)
+			if l == nil || l.Params["range"] != rg || l.URL != "http://www.example.com/people?sort=name" {
+				t.Fatal(cr, rel, "Got:", l, "Wanted:", rg)
+			}
+		}
+	}
+	test(&ContentRange{&Range{Unit: "items", From: 0, To: 9}, 95}, map[string]string{
+		"first": "items=0-9",
+		"next":  "items=10-19",
+		"last":  "items=90-94",
+	})
+	test(&ContentRange{&Range{Unit: "items", From: 15, To: 24}, 95}, map[string]string{
+		"first": "items=0-9",
+		"prev":  "items=5-14",
+		"next":  "items=25-34",
+		"last":  "items=90-94",
+	})
+	test(&ContentRange{&Range{Unit: "items", From: 90, To: 94}, 95}, map[string]string{
+		"first": "items=0-4",
+		"prev":  "items=85-89",
+		"last":  "items=90-94",
+	})
+	// Unknown number of items.
+	test(&ContentRange{&Range{Unit: "items", From: 10, To: 19}, 0}, map[string]string{
+		"first": "items=0-9",
+		"prev":  "items=0-9",
+		"next":  "items=20-29",
+	})
+}
+
+func TestPaginatorPageLinks(t *testing.T) {
+	p := &Paginator{PageSize: 10}
+	var test = func(rawurl string, count uint64, expected map[string]string) {
+		r, _ := http.NewRequest(Get, rawurl, nil)
+		links := p.PageLinks(r, p.Page(r), count)
+		if len(links) != len(expected) {
+			t.Fatal(rawurl, "Got:", links, "Wanted:", expected)
+		}
+		for rel, u := range expected {
+			if l := links.Get(rel); l == nil || l.URL != u {
+				t.Fatal(rawurl, rel, "Got:", l, "Wanted:", u)
+			}
+		}
+	}
+	test("http://www.example.com/people", 25, map[string]string{
+		"first": "http://www.example.com/people?page=1",
+		"next":  "http://www.example.com/people?page=2",
+		"last":  "http://www.example.com/people?page=3",
+	})
+	test("http://www.example.com/people?page=3&sort=name", 25, map[string]string{
+		"first": "http://www.example.com/people?page=1&sort=name",
+		"prev":  "http://www.example.com/people?page=2&sort=name",
+		"last":  "http://www.example.com/people?page=3&sort=name",
+	})
+	test("http://www.example.com/people?page=2", UnknownCount, map[string]string{
+		"first": "http://www.example.com/people?page=1",
+		"prev":  "http://www.example.com/people?page=1",
+		"next":  "http://www.example.com/people?page=3",
+	})
+	test("http://www.example.com/people", 0, map[string]string{
+		"first": "http://www.example.com/people?page=1",
+		"last":  "http://www.example.com/people?page=1",
+	})
+
+	if rg := p.PageRange("items", 3); rg.From != 20 || rg.To != 29 {
+		t.Fatal("Got:", rg.From, rg.To, "Wanted: 20 29")
+	}
+
+	// The page size defaults to the length of the requested range, or to
+	// DefaultPageSize.
+	p = &Paginator{}
+	if rg := p.PageRange("items", 2); rg.From != DefaultPageSize || rg.To != 2*DefaultPageSize-1 {
+		t.Fatal("Got:", rg.From, rg.To, "Wanted:", DefaultPageSize, 2*DefaultPageSize-1)
+	}
+	r, _ := http.NewRequest(Get, "http://www.example.com/people", nil)
+	if links := p.PageLinks(r, 1, 45); links.Get("next") == nil || links.Get("last").URL != "http://www.example.com/people?page=3" {
+		t.Fatal("Got:", links)
+	}
+	r.Header.Set("Range", "items=0-9")
+	if links := p.PageLinks(r, 4, 45); links.Get("next") == nil || links.Get("last").URL != "http://www.example.com/people?page=5" {
+		t.Fatal("Got:", links)
+	}
+}
+
+func TestPaginatorCursorLinks(t *testing.T) {
+	p := &Paginator{}
+	r, _ := http.NewRequest(Get, "http://www.example.com/people?cursor=abc", nil)
+	if cursor := p.Cursor(r); cursor != "abc" {
+		t.Fatal("Got:", cursor, "Wanted: abc")
+	}
+
+	links := p.CursorLinks(r, "", "def")
+	if len(links) != 2 || links.Get("first").URL != "http://www.example.com/people" {
+		t.Fatal("unexpected links:", links)
+	}
+	if next := links.Get("next"); next == nil || next.URL != "http://www.example.com/people?cursor=def" {
+		t.Fatal("unexpected next link:", next)
+	}
+}
+
+type linkedResource struct {
+	*Envelope
+}
+
+func (l *linkedResource) Links(r *http.Request) Links {
+	return (&Paginator{PageSize: 10}).CursorLinks(r, "", "next-cursor")
+}
+
+func TestLinkHeader(t *testing.T) {
+	mux := NewMux()
+	mux.Get("/linked", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return &linkedResource{NewEnvelope("linked", testTimeReference, "", 0)}, nil
+	})
+	r, _ := http.NewRequest(Get, "http://www.example.com/linked", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	if link := w.Header().Get("Link"); link != `<http://www.example.com/linked>; rel="first", <http://www.example.com/linked?cursor=next-cursor>; rel="next"` {
+		t.Fatal("unexpected Link header:", link)
+	}
+
+	// Partial responses are paginated when enabled in the mux.
+	mux.Handle("/people", EndpointHandler(&peopleCollection{}))
+	var get = func() *httptest.ResponseRecorder {
+		r, _ := http.NewRequest(Get, "http://www.example.com/people", nil)
+		r.Header.Set("Accept", "application/json")
+		r.Header.Set("Range", "resources=10-19")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w
+	}
+	if w := get(); w.Code != http.StatusPartialContent || w.Header().Get("Link") != "" {
+		t.Fatal("Got:", w.Code, w.Header(), "Wanted: no Link header")
+	}
+	mux.PaginateRanges = true
+	w = get()
+	if w.Code != http.StatusPartialContent {
+		t.Fatal("Got:", w.Code, "Wanted:", http.StatusPartialContent)
+	}
+	link := w.Header().Get("Link")
+	if !strings.Contains(link, `rel="next"; range="resources=20-29"`) || !strings.Contains(link, `rel="prev"; range="resources=0-9"`) {
+		t.Fatal("unexpected Link header:", link)
+	}
+}
@@ -0,0 +1,476 @@
+package rst
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Media types of the patch documents understood by ApplyPatch.
+const (
+	MergePatchType = "application/merge-patch+json" // RFC 7396
+	JSONPatchType  = "application/json-patch+json"  // RFC 6902
+)
+
+// patchTypes are the media types listed in the Accept-Patch header.
+var patchTypes = []string{MergePatchType, JSONPatchType}
+
+// unsupportedPatchType returns the error returned for patch documents in an
+// unknown format.
+func unsupportedPatchType() *Error {
+	err := UnsupportedMediaType(patchTypes...)
+	err.Header.Set("Accept-Patch", strings.Join(patchTypes, ", "))
+	return err
+}
+
+/*
+ApplyPatch applies the patch document in the body of r to v, which must be a
+pointer to a value that can be encoded in JSON. The patch can be a JSON Merge
+Patch (RFC 7396) or a JSON Patch (RFC 6902), as indicated by the Content-Type
+header of r.
+
+	func (ep *PersonEP) Patch(vars rst.RouteVars, r *http.Request) (rst.Resource, error) {
+		person := database.Find(vars.Get("id"))
+		if person == nil {
+			return nil, rst.NotFound()
+		}
+		if err := rst.ApplyPatch(r, person); err != nil {
+			return nil, err
+		}
+		return person, database.Save(person)
+	}
+
+The patched document is decoded in a new value of the type of v, which then
+replaces v if it's valid. Members removed by the patch are reset, and so are the
+fields ignored by encoding/json. Values implementing Validatable are validated.
+
+The errors returned are:
+  - UnsupportedMediaType, with an Accept-Patch header, for other formats.
+  - BadRequest if the patch document is malformed.
+  - UnprocessableEntity if an operation is invalid, or if the patched
+    document can't be decoded in v or isn't valid.
+  - Conflict if an operation can't be applied to v, like a failed "test".
+*/
+func ApplyPatch(r *http.Request, v interface{}) error {
+	doc, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	patched, err := PatchDocument(r, doc)
+	if err != nil {
+		return err
+	}
+
+	// Patched documents are decoded in a new value so that removed members
+	// are reset.
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("rst: ApplyPatch requires a non-nil pointer, got %T", v)
+	}
+	fresh := reflect.New(rv.Elem().Type())
+	if err := json.Unmarshal(patched, fresh.Interface()); err != nil {
+		e := UnprocessableEntity()
+		e.Description = fmt.Sprintf("The patched resource could not be decoded: %s.", err)
+		return e
+	}
+	if validatable, implemented := fresh.Interface().(Validatable); implemented {
+		validator := new(Validator)
+		validatable.Validate(validator)
+		if err := validator.Err(); err != nil {
+			return err
+		}
+	}
+	rv.Elem().Set(fresh.Elem())
+	return nil
+}
+
+// PatchDocument applies the patch document in the body of r to doc, a JSON
+// document, and returns the result. See ApplyPatch for the formats supported and
+// the errors returned.
+func PatchDocument(r *http.Request, doc []byte) ([]byte, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != MergePatchType && mediaType != JSONPatchType {
+		return nil, unsupportedPatchType()
+	}
+
+	patch, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if mediaType == MergePatchType {
+		return MergePatch(doc, patch)
+	}
+	return JSONPatch(doc, patch)
+}
+
+// decodeJSON decodes b, keeping numbers as json.Number to preserve them. b
+// must hold a single JSON value.
+func decodeJSON(b []byte) (interface{}, error) {
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return nil, decodeError(err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, BadRequest("", "Unexpected data after the JSON document.")
+	}
+	return v, nil
+}
+
+// MergePatch applies patch, a JSON Merge Patch as defined in RFC 7396, to doc
+// and returns the result.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	target, err := decodeJSON(doc)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decodeJSON(patch)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergePatch(target, p))
+}
+
+// mergePatch returns target merged with patch.
+func mergePatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = make(map[string]interface{})
+	}
+	for name, value := range p {
+		if value == nil {
+			delete(t, name)
+		} else {
+			t[name] = mergePatch(t[name], value)
+		}
+	}
+	return t
+}
+
+// patchOperation is an operation of a JSON Patch.
+type patchOperation struct {
+	Op    string           `json:"op"`
+	Path  *string          `json:"path"`
+	From  *string          `json:"from"`
+	Value *json.RawMessage `json:"value"`
+}
+
+// patchViolation returns the error returned for the invalid member of the
+// operation found at index in a JSON Patch.
+func patchViolation(index int, member, message string) *Error {
+	return UnprocessableEntity(&Violation{
+		Path:    JSONPointer(strconv.Itoa(index), member),
+		Code:    "invalid",
+		Message: message,
+	})
+}
+
+// patchConflict returns the error returned when the operation found at index
+// in a JSON Patch can't be applied.
+func patchConflict(index int, description string) *Error {
+	err := Conflict()
+	err.Description = fmt.Sprintf("Operation %d of the patch could not be applied: %s.", index, description)
+	return err
+}
+
+// JSONPatch applies patch, a JSON Patch as defined in RFC 6902, to doc and
+// returns the result. Operations are applied in order, and none is applied if
+// one of them fails.
+func JSONPatch(doc, patch []byte) ([]byte, error) {
+	target, err := decodeJSON(doc)
+	if err != nil {
+		return nil, err
+	}
+	var ops []*patchOperation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, decodeError(err)
+	}
+
+	for i, op := range ops {
+		if op == nil || op.Path == nil {
+			return nil, patchViolation(i, "path", "Operation has no path.")
+		}
+		path, err := parsePointer(*op.Path)
+		if err != nil {
+			return nil, patchViolation(i, "path", err.Error())
+		}
+
+		var value interface{}
+		switch op.Op {
+		case "add", "replace", "test":
+			if op.Value == nil {
+				return nil, patchViolation(i, "value", "Operation has no value.")
+			}
+			if value, err = decodeJSON(*op.Value); err != nil {
+				return nil, patchViolation(i, "value", "Value is not valid JSON.")
+			}
+		case "move", "copy":
+			if op.From == nil {
+				return nil, patchViolation(i, "from", "Operation has no from.")
+			}
+			from, err := parsePointer(*op.From)
+			if err != nil {
+				return nil, patchViolation(i, "from", err.Error())
+			}
+			if value, err = getPointer(target, from); err != nil {
+				return nil, patchConflict(i, err.Error())
+			}
+			if op.Op == "copy" {
+				value = copyValue(value)
+			}
+			if op.Op == "move" {
+				if strings.HasPrefix(*op.Path, *op.From+"/") {
+					return nil, patchViolation(i, "from", "A value can't be moved into one of its children.")
+				}
+				if target, err = updatePointer(target, from, removeValue); err != nil {
+					return nil, patchConflict(i, err.Error())
+				}
+			}
+		case "remove":
+		default:
+			return nil, patchViolation(i, "op", fmt.Sprintf("Unknown operation %q.", op.Op))
+		}
+
+		switch op.Op {
+		case "add", "move", "copy":
+			target, err = updatePointer(target, path, addValue(value))
+		case "replace":
+			target, err = updatePointer(target, path, replaceValue(value))
+		case "remove":
+			target, err = updatePointer(target, path, removeValue)
+		case "test":
+			var current interface{}
+			if current, err = getPointer(target, path); err == nil && !jsonEqual(current, value) {
+				err = fmt.Errorf("value at %s does not match", *op.Path)
+			}
+		}
+		if err != nil {
+			return nil, patchConflict(i, err.Error())
+		}
+	}
+	return json.Marshal(target)
+}
+
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// parsePointer returns the reference tokens of pointer, a JSON pointer as
+// defined in RFC 6901.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = pointerUnescaper.Replace(token)
+	}
+	return tokens, nil
+}
+
+// arrayIndex returns the index referenced by token in an array of length n.
+// The index n itself is only valid if end is true.
+func arrayIndex(token string, n int, end bool) (int, error) {
+	if end && token == "-" {
+		return n, nil
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	if i > n || (i == n && !end) {
+		return 0, fmt.Errorf("array index %d out of bounds", i)
+	}
+	return i, nil
+}
+
+// getPointer returns the value referenced by tokens in doc.
+func getPointer(doc interface{}, tokens []string) (interface{}, error) {
+	for _, token := range tokens {
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("member %q not found", token)
+			}
+			doc = value
+		case []interface{}:
+			i, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[i]
+		default:
+			return nil, fmt.Errorf("%q can't be found in a scalar value", token)
+		}
+	}
+	return doc, nil
+}
+
+// pointerUpdate returns parent, an object or an array, after the change of its
+// child identified by token.
+type pointerUpdate func(parent interface{}, token string) (interface{}, error)
+
+// updatePointer applies fn to the parent of the value referenced by tokens in
+// doc, and returns the updated document. The root document is replaced by fn
+// applied to nil if tokens is empty.
+func updatePointer(doc interface{}, tokens []string, fn pointerUpdate) (interface{}, error) {
+	if len(tokens) == 0 {
+		return fn(nil, "")
+	}
+	if len(tokens) == 1 {
+		return fn(doc, tokens[0])
+	}
+
+	child, err := getPointer(doc, tokens[:1])
+	if err != nil {
+		return nil, err
+	}
+	if child, err = updatePointer(child, tokens[1:], fn); err != nil {
+		return nil, err
+	}
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		node[tokens[0]] = child
+	case []interface{}:
+		i, _ := arrayIndex(tokens[0], len(node), false)
+		node[i] = child
+	}
+	return doc, nil
+}
+
+// addValue returns an update adding value to its parent.
+func addValue(value interface{}) pointerUpdate {
+	return func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case nil:
+			return value, nil
+		case map[string]interface{}:
+			node[token] = value
+			return node, nil
+		case []interface{}:
+			i, err := arrayIndex(token, len(node), true)
+			if err != nil {
+				return nil, err
+			}
+			node = append(node, nil)
+			copy(node[i+1:], node[i:])
+			node[i] = value
+			return node, nil
+		}
+		return nil, fmt.Errorf("%q can't be added to a scalar value", token)
+	}
+}
+
+// removeValue removes a value from its parent.
+func removeValue(parent interface{}, token string) (interface{}, error) {
+	switch node := parent.(type) {
+	case nil:
+		return nil, fmt.Errorf("the root of the document can't be removed")
+	case map[string]interface{}:
+		if _, ok := node[token]; !ok {
+			return nil, fmt.Errorf("member %q not found", token)
+		}
+		delete(node, token)
+		return node, nil
+	case []interface{}:
+		i, err := arrayIndex(token, len(node), false)
+		if err != nil {
+			return nil, err
+		}
+		return append(node[:i], node[i+1:]...), nil
+	}
+	return nil, fmt.Errorf("%q can't be removed from a scalar value", token)
+}
+
+// replaceValue returns an update replacing an existing value of its parent.
+func replaceValue(value interface{}) pointerUpdate {
+	return func(parent interface{}, token string) (interface{}, error) {
+		if parent == nil {
+			return value, nil
+		}
+		if _, err := getPointer(parent, []string{token}); err != nil {
+			return nil, err
+		}
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			node[token] = value
+		case []interface{}:
+			i, _ := arrayIndex(token, len(node), false)
+			node[i] = value
+		}
+		return parent, nil
+	}
+}
+
+// copyValue returns a deep copy of v, decoded by decodeJSON.
+func copyValue(v interface{}) interface{} {
+	switch node := v.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(node))
+		for k, child := range node {
+			c[k] = copyValue(child)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(node))
+		for i, child := range node {
+			c[i] = copyValue(child)
+		}
+		return c
+	}
+	return v
+}
+
+// jsonEqual returns true if a and b, decoded by decodeJSON, are equal.
+func jsonEqual(a, b interface{}) bool {
+	switch va := a.(type) {
+	case json.Number:
+		vb, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		if va == vb {
+			return true
+		}
+		fa, errA := va.Float64()
+		fb, errB := vb.Float64()
+		return errA == nil && errB == nil && fa == fb
+	case map[string]interface{}:
+		vb, ok := b.(map[string]interface{})
+		if !ok || len(va) != len(vb) {
+			return false
+		}
+		for k, v := range va {
+			if w, ok := vb[k]; !ok || !jsonEqual(v, w) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		vb, ok := b.([]interface{})
+		if !ok || len(va) != len(vb) {
+			return false
+		}
+		for i := range va {
+			if !jsonEqual(va[i], vb[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return a == b
+}
@@ -0,0 +1,220 @@
+package rst
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// jsonDocument decodes s for comparisons.
+func jsonDocument(t *testing.T, s string) interface{} {
+	v, err := decodeJSON([]byte(s))
+	if err != nil {
+		t.Fatal(s, err)
+	}
+	return v
+}
+
+func TestMergePatch(t *testing.T) {
+	// Examples from Appendix A of RFC 7396.
+	var tests = []struct{ doc, patch, expected string }{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, test := range tests {
+		b, err := MergePatch([]byte(test.doc), []byte(test.patch))
+		if err != nil {
+			t.Fatal(test.patch, err)
+		}
+		if got, wanted := jsonDocument(t, string(b)), jsonDocument(t, test.expected); !reflect.DeepEqual(got, wanted) {
+			t.Fatal(test.doc, test.patch, "Got:", string(b), "Wanted:", test.expected)
+		}
+	}
+
+	for _, patch := range []string{`{"a":`, `{"a":1} {"b":2}`, `{"a":1}]`} {
+		if _, err := MergePatch([]byte(`{}`), []byte(patch)); err == nil || err.(*Error).Code != http.StatusBadRequest {
+			t.Fatal(patch, "Got:", err, "Wanted:", http.StatusBadRequest)
+		}
+	}
+	if _, err := MergePatch([]byte(`{}`), []byte(`{"a":1}`+"\n")); err != nil {
+		t.Fatal("trailing whitespace is expected to be accepted:", err)
+	}
+}
+
+func TestJSONPatch(t *testing.T) {
+	// Examples from Appendix A of RFC 6902.
+	var tests = []struct{ doc, patch, expected string }{
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		{`{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{`{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{`{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{`{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		{`{"baz":"qux","foo":["a",2,"c"]}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2.0}]`, `{"baz":"qux","foo":["a",2,"c"]}`},
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/child","value":{"grandchild":{}}}]`, `{"foo":"bar","child":{"grandchild":{}}}`},
+		{`{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`, `{"foo":["bar",["abc","def"]]}`},
+		{`{"/":9,"~1":10}`, `[{"op":"test","path":"/~01","value":10},{"op":"copy","from":"/~1","path":"/a"}]`, `{"/":9,"~1":10,"a":9}`},
+		{`{"foo":"bar"}`, `[{"op":"replace","path":"","value":[1]}]`, `[1]`},
+		// Copies don't share their children with the original.
+		{`{"a":{"x":1}}`, `[{"op":"copy","from":"/a","path":"/b"},{"op":"add","path":"/b/y","value":2}]`, `{"a":{"x":1},"b":{"x":1,"y":2}}`},
+		{`{"a":[1,2]}`, `[{"op":"copy","from":"/a","path":"/b"},{"op":"replace","path":"/b/0","value":9}]`, `{"a":[1,2],"b":[9,2]}`},
+		// A value can be moved onto itself, or next to a sibling sharing its prefix.
+		{`{"a":{"b":1}}`, `[{"op":"move","from":"/a","path":"/a"}]`, `{"a":{"b":1}}`},
+		{`{"a":1}`, `[{"op":"move","from":"/a","path":"/ab"}]`, `{"ab":1}`},
+	}
+	for _, test := range tests {
+		b, err := JSONPatch([]byte(test.doc), []byte(test.patch))
+		if err != nil {
+			t.Fatal(test.patch, err)
+		}
+		if got, wanted := jsonDocument(t, string(b)), jsonDocument(t, test.expected); !reflect.DeepEqual(got, wanted) {
+			t.Fatal(test.doc, test.patch, "Got:", string(b), "Wanted:", test.expected)
+		}
+	}
+}
+
+func TestJSONPatchErrors(t *testing.T) {
+	var test = func(doc, patch string, code int, path string) {
+		_, err := JSONPatch([]byte(doc), []byte(patch))
+		e, ok := err.(*Error)
+		if !ok || e.Code != code {
+			t.Fatal(patch, "Got:", err, "Wanted:", code)
+		}
+		if path != "" && (len(e.Violations) != 1 || e.Violations[0].Path != path) {
+			t.Fatal(patch, "Got:", e.Violations, "Wanted:", path)
+		}
+	}
+	test(`{}`, `{"op":"add"}`, http.StatusBadRequest, "")
+	test(`{"baz":"qux"}`, `[{"op":"test","path":"/baz","value":"bar"}]`, http.StatusConflict, "")
+	test(`{"foo":"bar"}`, `[{"op":"add","path":"/baz/bat","value":"qux"}]`, http.StatusConflict, "")
+	test(`{"foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, http.StatusConflict, "")
+	test(`{"foo":"bar"}`, `[{"op":"replace","path":"/baz","value":1}]`, http.StatusConflict, "")
+	test(`{"foo":[1]}`, `[{"op":"add","path":"/foo/01","value":1}]`, http.StatusConflict, "")
+	test(`{"foo":[1]}`, `[{"op":"remove","path":"/foo/1"}]`, http.StatusConflict, "")
+	test(`{}`, `[{"op":"add","path":"/a","value":1},{"op":"move","path":"/a"}]`, http.StatusUnprocessableEntity, "/1/from")
+	test(`{}`, `[{"op":"add","path":"/a"}]`, http.StatusUnprocessableEntity, "/0/value")
+	test(`{}`, `[{"op":"add","value":1}]`, http.StatusUnprocessableEntity, "/0/path")
+	test(`{}`, `[{"op":"add","path":"a","value":1}]`, http.StatusUnprocessableEntity, "/0/path")
+	test(`{}`, `[{"op":"foo","path":"/a"}]`, http.StatusUnprocessableEntity, "/0/op")
+	test(`{"a":{"b":1}}`, `[{"op":"move","from":"/a","path":"/a/c"}]`, http.StatusUnprocessableEntity, "/0/from")
+}
+
+type patchedPerson struct {
+	Name    string   `json:"name"`
+	Age     int      `json:"age,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+	Version int      `json:"-"`
+}
+
+func (p *patchedPerson) Validate(v *Validator) {
+	v.Check(p.Name != "", "/name", "required", "Name is required.")
+}
+
+func (p *patchedPerson) ETag() string            { return "" }
+func (p *patchedPerson) LastModified() time.Time { return testTimeReference }
+func (p *patchedPerson) TTL() time.Duration      { return 0 }
+
+func TestApplyPatch(t *testing.T) {
+	var test = func(contentType, patch string) (*patchedPerson, error) {
+		p := &patchedPerson{Name: "John", Age: 42, Tags: []string{"a"}, Version: 3}
+		r, _ := http.NewRequest(Patch, "http://www.example.com/people/1", strings.NewReader(patch))
+		r.Header.Set("Content-Type", contentType)
+		return p, ApplyPatch(r, p)
+	}
+
+	p, err := test(MergePatchType, `{"age":null,"tags":["b","c"]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (&patchedPerson{Name: "John", Tags: []string{"b", "c"}}); !reflect.DeepEqual(p, expected) {
+		t.Fatal("Got:", p, "Wanted:", expected)
+	}
+
+	p, err = test(JSONPatchType+"; charset=utf-8", `[{"op":"test","path":"/age","value":42},{"op":"add","path":"/tags/0","value":"z"}]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (&patchedPerson{Name: "John", Age: 42, Tags: []string{"z", "a"}}); !reflect.DeepEqual(p, expected) {
+		t.Fatal("Got:", p, "Wanted:", expected)
+	}
+
+	var testError = func(contentType, patch string, code int) *Error {
+		p, err := test(contentType, patch)
+		e, ok := err.(*Error)
+		if !ok || e.Code != code {
+			t.Fatal(patch, "Got:", err, "Wanted:", code)
+		}
+		if p.Name != "John" || p.Age != 42 {
+			t.Fatal(patch, "value modified by a patch that failed:", p)
+		}
+		return e
+	}
+	e := testError("application/json", `{"name":"Jane"}`, http.StatusUnsupportedMediaType)
+	if accept := e.Header.Get("Accept-Patch"); accept != MergePatchType+", "+JSONPatchType {
+		t.Fatal("Got:", accept, "Wanted:", MergePatchType+", "+JSONPatchType)
+	}
+	testError(JSONPatchType, `[{"op":"test","path":"/age","value":41}]`, http.StatusConflict)
+	testError(MergePatchType, `{"age":"old"}`, http.StatusUnprocessableEntity)
+	e = testError(MergePatchType, `{"name":""}`, http.StatusUnprocessableEntity)
+	if len(e.Violations) != 1 || e.Violations[0].Path != "/name" {
+		t.Fatal("Got:", e.Violations, "Wanted: a violation of /name")
+	}
+}
+
+func TestPatchHandler(t *testing.T) {
+	mux := NewMux()
+	mux.Patch("/people/{id}", func(vars RouteVars, r *http.Request) (Resource, error) {
+		p := &patchedPerson{Name: "John", Age: 42}
+		if err := ApplyPatch(r, p); err != nil {
+			return nil, err
+		}
+		return p, nil
+	})
+
+	r, _ := http.NewRequest(Options, "http://www.example.com/people/1", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	if accept := w.Header().Get("Accept-Patch"); accept != MergePatchType+", "+JSONPatchType {
+		t.Fatal("Got:", accept, "Wanted:", MergePatchType+", "+JSONPatchType)
+	}
+
+	r, _ = http.NewRequest(Patch, "http://www.example.com/people/1", bytes.NewBufferString(`{"age":43}`))
+	r.Header.Set("Content-Type", MergePatchType)
+	r.Header.Set("Accept", "application/json")
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatal("Got:", w.Code, "Wanted:", http.StatusOK)
+	}
+	var p patchedPerson
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil || p.Age != 43 {
+		t.Fatal("Got:", w.Body.String(), err)
+	}
+
+	r, _ = http.NewRequest(Patch, "http://www.example.com/people/1", bytes.NewBufferString(`age=43`))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	if w.Code != http.StatusUnsupportedMediaType || w.Header().Get("Accept-Patch") == "" {
+		t.Fatal("Got:", w.Code, w.Header(), "Wanted: 415 with an Accept-Patch header")
+	}
+}
@@ -126,6 +126,25 @@ handle GET requests.
 		return resource.Delete()
 	}
 
+Endpoints that need the context of the request, for instance to cancel calls to
+a database when the client goes away, can implement GetterContext,
+PosterContext, PatcherContext, PutterContext and/or DeleterContext instead:
+
+	mux.GetContext("/people/{id:\\d+}", func(ctx context.Context, vars rst.RouteVars, r *http.Request) (rst.Resource, error) {
+		return database.FindContext(ctx, vars.Get("id"))
+	})
+
+Their context expires after the Timeout of the mux, which can be overridden for
+each route. A 504 Gateway Timeout error is returned if the endpoint hasn't
+returned by then, and a 503 Service Unavailable error if the request was
+canceled.
+
+	mux.Timeout = 5 * time.Second
+	mux.GetContext("/reports/{id}", getReport).Timeout(time.Minute)
+
+The variables extracted from the URL and the matched route are carried by the
+context of the request, and can be retrieved with Vars and CurrentRoute.
+
 Routing
 
 Routing of requests in rst is powered by Gorilla mux
@@ -143,6 +162,49 @@ Optional regular expressions are supported.
 
 	http.ListenAndServe(":8080", mux)
 
+Groups of routes sharing a prefix can have their own headers, CORS policy,
+codecs, middleware and flags:
+
+	admin := mux.Group("/admin")
+	admin.Header().Set("Cache-Control", "private")
+	admin.SetCORSPolicy(nil)
+	admin.SetDebug(false) // even if mux.Debug is true
+	admin.HandleEndpoint("/users/{id}", &UserEP{}) // matches /admin/users/{id}
+
+Named routes can be used to build URLs, for instance in the location returned
+by a Poster, which is resolved against the URL of the request when relative:
+
+	mux.HandleEndpoint("/people/{id:\\d+}", &PersonEP{}).Name("person")
+
+	u, err := mux.URL("person", rst.RouteVars{"id": "42"}) // /people/42
+
+The routes registered in a mux can be listed with Routes, and described in an
+OpenAPI 3 document with the openapi package:
+
+	for _, route := range mux.Routes() {
+		fmt.Println(route.Pattern(), route.GetName(), route.Methods())
+	}
+
+	doc := openapi.Generate(mux, openapi.Info{Title: "People", Version: "1.0"})
+
+Middleware
+
+A Middleware runs after routing, and has access to the matched route, the
+variables extracted from the URL, and the endpoint. It can interrupt the chain
+by returning an error.
+
+	mux.Use(func(route *rst.Route, vars rst.RouteVars, w http.ResponseWriter, r *http.Request, next http.Handler) error {
+		if r.Header.Get("Authorization") == "" {
+			return rst.Unauthorized()
+		}
+		next.ServeHTTP(w, r)
+		return nil
+	})
+
+Middleware can also be attached to a single route:
+
+	mux.HandleEndpoint("/people/{id:\\d+}", &PersonEP{}).Use(audit)
+
 Encoding
 
 rst supports JSON, XML and text encoding of resources using the encoders in Go's
@@ -152,8 +214,56 @@ It negotiates the right encoding format based on the content of the Accept
 header in the request, calls the appropriate marshaler, and inserts the result
 in a response with the right status code and headers.
 
-You can implement the Marshaler interface if you want to add support for another
-format, or for more control over the encoding process of a specific resource.
+Support for another format can be added to all resources by registering a Codec
+in DefaultCodecs, or in a registry specific to a Mux.
+
+	mux.Codecs = rst.NewCodecs(rst.JSONCodec, rst.XMLCodec, msgpackCodec)
+
+You can also implement the Marshaler interface for more control over the
+encoding process of a specific resource.
+
+Decoding
+
+rst.Unmarshal decodes the entity found in the body of a request using the
+decoder that matches its Content-Type header. JSON, XML and form-urlencoded
+entities are supported.
+
+	func (ep *PeopleEP) Post(vars rst.RouteVars, r *http.Request) (rst.Resource, string, error) {
+		person := new(Person)
+		if err := rst.Unmarshal(r, person); err != nil {
+			return nil, "", err // 400 Bad Request or 415 Unsupported Media Type
+		}
+		// save person
+	}
+
+You can implement the Unmarshaler interface for more control over the decoding
+process of a specific type.
+
+Types implementing Validatable are validated once decoded, and the violations
+reported to the Validator are returned in a 422 Unprocessable Entity error.
+
+	func (p *Person) Validate(v *rst.Validator) {
+		v.Check(p.Name != "", "/name", "required", "Name is required.")
+	}
+
+Patching
+
+rst.ApplyPatch applies a JSON Merge Patch (application/merge-patch+json) or a
+JSON Patch (application/json-patch+json) found in the body of a request to a Go
+value. MergePatch and JSONPatch apply them to JSON documents.
+
+	func (ep *PersonEP) Patch(vars rst.RouteVars, r *http.Request) (rst.Resource, error) {
+		person := database.Find(vars.Get("id"))
+		if err := rst.ApplyPatch(r, person); err != nil {
+			return nil, err // 409 Conflict if a "test" operation failed
+		}
+		return person, database.Save(person)
+	}
+
+Both formats are listed in the Accept-Patch header of the responses to OPTIONS
+requests on endpoints implementing Patcher, and of 415 Unsupported Media Type
+errors returned by ApplyPatch. Invalid operations are reported in a 422
+Unprocessable Entity error.
 
 Compression
 
@@ -164,19 +274,94 @@ Payloads under the size defined in the CompressionThreshold const are not compre
 
 Both Gzip and Flate are supported.
 
+Streaming
+
+Resources implementing StreamMarshaler write their representation directly in
+the response, and resources implementing Opener return an io.Reader to copy in
+it. Streamed responses are never buffered in memory: they're sent with chunked
+transfer encoding, and compressed on the fly. The ETag, Last-Modified and Vary
+headers are sent before the first byte. Errors returned once the body has
+started abort the response, so that clients can tell it is incomplete.
+
+	func (e *Export) StreamContentType(r *http.Request) (string, error) {
+		return "text/csv; charset=utf-8", nil
+	}
+
+	func (e *Export) MarshalStream(w io.Writer, r *http.Request) error {
+		return e.WriteCSV(w)
+	}
+
+Large collections can be returned as a Collection, which streams the items of an
+Iterator: one per line to clients accepting application/x-ndjson or
+application/jsonl, or in a JSON array or an XML list otherwise, encoded with
+the codecs of the mux. Items are flushed at most every CollectionFlushInterval
+of the mux.
+
+	return rst.NewCollection(rst.ChanIterator(people), time.Now(), "", 0), nil
+
+Server-Sent Events
+
+Endpoints implementing EventSource push events to clients accepting
+text/event-stream, while other GET requests are still served by their Getter.
+Events are read from a channel until it's closed or the client disconnects,
+and their data is encoded with the negotiated codec. A comment is sent after
+the EventHeartbeat interval of the mux without events to keep idle connections
+open, and clients resuming a stream are identified with LastEventID.
+
+	mux.Events("/prices", func(ctx context.Context, vars rst.RouteVars, r *http.Request) (<-chan *rst.Event, error) {
+		return feed.Subscribe(ctx, rst.LastEventID(r)), nil
+	})
+
 Options
 
 OPTIONS requests are implicitly supported by all endpoints.
 
 Cache
 
-The ETag, Last-Modified and Vary headers are automatically set.
+The ETag, Last-Modified and Vary headers are automatically set. Entity tags are
+quoted for you, and can be marked as weak with WeakETag.
+
+When AutoETag is set in a mux, resources returning an empty ETag are identified
+by a hash of their encoded representation. The tag is weak when the response is
+compressed.
+
+Conditional requests are evaluated as defined in RFC 7232: If-Match,
+If-Unmodified-Since, If-None-Match and If-Modified-Since are checked in that
+order of precedence, with the strong comparison of entity tags for If-Match and
+the weak one for If-None-Match. rst responds to GET and HEAD requests with
+304 NOT MODIFIED, or with 412 PRECONDITION FAILED when a condition fails.
+
+Other endpoints can evaluate the same conditions with EvaluateConditions.
+
+Endpoints implementing Loader let rst evaluate the conditions of PUT, PATCH and
+DELETE requests before they're called. Setting RequireConditions in a mux makes
+these requests fail with 428 PRECONDITION REQUIRED when they're not conditional,
+and the resource they modify has an ETag or a modification date.
 
-rst responds with 304 NOT MODIFIED when an appropriate If-Modified-Since or
-If-None-Match header is found in the request.
+	func (ep *PersonEP) Load(vars rst.RouteVars, r *http.Request) (rst.Resource, error) {
+		return database.Find(vars.Get("id")), nil
+	}
+
+	mux.RequireConditions = true
 
 The Expires header is also automatically inserted with the duration returned by
-Resource.TTL().
+Resource.TTL(), along with a Cache-Control header with the matching max-age.
+
+Resources implementing CacheController can return a richer policy:
+
+	func (p *Person) CacheControl() *rst.CacheControl {
+		return &rst.CacheControl{Private: true, MaxAge: p.TTL(), StaleIfError: time.Hour}
+	}
+
+Responses can also be cached in memory by a ResponseCache used as a middleware.
+Variants are selected with the headers listed in Vary, and can be purged by
+route pattern, or by the tags resources list in their Cache-Tag header.
+Streamed responses are passed through and never stored.
+
+	cache := rst.NewResponseCache()
+	mux.Use(cache.Middleware)
+	...
+	cache.PurgePattern("/people/{id}")
 
 Partial Gets
 
@@ -190,9 +375,85 @@ incoming GET request.
 The Accept-Range header will be inserted automatically.
 
 The supported range units and the range extent will be validated for you.
+Suffix ranges like "bytes=-500", which request the last 500 units, are resolved
+against Ranger.Count() before Ranger.Range is called. Requests for ranges that
+can't be satisfied, including any range of an empty resource, fail with
+416 REQUESTED RANGE NOT SATISFIABLE and a Content-Range header carrying the
+unit and the total number of units available.
+
+Requests for several ranges, such as "bytes=0-99,200-299", are answered with a
+multipart/byteranges response. Overlapping and adjacent ranges are coalesced,
+and Ranger.Range is called for each of the remaining ones. Requests for more
+than MaxRanges ranges are served in full.
 
 Note that the If-Range conditional header is supported as well.
 
+Pagination
+
+Partial responses in units other than bytes can be treated as pages of a
+collection: when PaginateRanges is set in the mux, rst adds a Link header
+(RFC 8288) with the ranges of the first, previous, next and last pages.
+
+	mux.PaginateRanges = true
+
+Collections paginated with a page number or an opaque cursor in the query
+string can implement Linker, and use a Paginator to compute their links:
+
+	func (c *Collection) Links(r *http.Request) rst.Links {
+		p := &rst.Paginator{PageSize: 20}
+		return p.PageLinks(r, p.Page(r), c.Count())
+	}
+
+Idempotency
+
+POST and PATCH requests can be made safe to retry with the Idempotency-Key
+header by the Idempotent middleware. The first response to each key is saved in
+an IdempotencyStore, and replayed to the retries of the request. Reusing a key
+while its request is in flight fails with 409 CONFLICT, and reusing it with a
+different payload fails with 422 UNPROCESSABLE ENTITY.
+
+	store := rst.NewMemoryIdempotencyStore(24*time.Hour, 5*time.Minute)
+	mux.Post("/payments", createPayment).Use(rst.Idempotent(store))
+
+Asynchronous Operations
+
+Posters can return a Job to run long operations in the background. The client
+receives a 202 ACCEPTED response with a Location header pointing to the status
+of the job, registered in the mux with HandleJobs. The status reports the
+progress of the job, and redirects with 303 SEE OTHER to its result once it has
+succeeded. DELETE requests on the status cancel the job.
+
+	mux.HandleJobs("/jobs")
+	mux.Post("/imports", func(vars rst.RouteVars, r *http.Request) (rst.Resource, string, error) {
+		return rst.NewJob(func(ctx context.Context, job *rst.Job) (string, error) {
+			job.SetProgress(0.5, "indexing")
+			return "/imports/42", nil
+		}), "", nil
+	})
+
+Clients sending a "Prefer: wait=10" header receive the final status of jobs
+finishing within 10 seconds instead of a 202 ACCEPTED response. The delay is
+capped by the MaxWait field of the mux handling jobs. Its JobRetention and
+JobPollInterval fields set how long the status of finished jobs is kept, and
+how often clients should poll unfinished ones.
+
+Preferences
+
+rst honors the preferences of clients found in the Prefer header (RFC 7240), and
+lists the ones it applied in the Preference-Applied header:
+
+	- return=minimal: responses to POST, PUT and PATCH requests have no body,
+	  but carry the Location and validators of the resource, with status
+	  201 CREATED or 204 NO CONTENT.
+	- return=representation: the resource is written in full, as by default.
+	- handling=lenient: resources are written in the preferred format of the
+	  mux instead of failing with 406 NOT ACCEPTABLE.
+	- handling=strict: invalid Range headers fail with 400 BAD REQUEST instead
+	  of being ignored.
+	- wait and respond-async: see Asynchronous Operations.
+
+Endpoints can read the other preferences with RequestPreferences.
+
 CORS
 
 rst can add the headers required to serve cross-origin (CORS) requests for you.
@@ -207,19 +468,29 @@ Support can be disabled by passing nil.
 Preflighted requests are also supported. However, you can customize the
 responses returned by preflight OPTIONS requests if you implement the
 Preflighter interface in your endpoint.
+
+Errors
+
+Errors returned by endpoints are rendered in HTML for browsers, and with the
+registered codecs otherwise. Clients accepting application/problem+json or
+application/problem+xml receive problem details as defined in RFC 7807.
+
+	err := rst.NewError(http.StatusForbidden, "You do not have enough credit.", "Your current balance is 30, but that costs 50.")
+	err.Type = "https://example.com/probs/out-of-credit"
+	err.Extensions["balance"] = 30
 */
 package rst
 
 import (
+	"context"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"time"
 
-	"github.com/gorilla/context"
 	gorillaMux "github.com/gorilla/mux"
 )
 
@@ -250,34 +521,46 @@ func (rv RouteVars) Get(key string) string {
 // support.
 type responseWriter struct {
 	http.ResponseWriter
-	wfl io.Writer
+	wfl        compressor // Compresses the body once started. Nil if not compressed.
+	uncompress bool       // Set if the body started without a known Content-Encoding.
 }
 
-// Flush sends content down the transport.
-func (rw *responseWriter) flush() {
-	if rw.wfl == nil {
-		return
+// Flush implements http.Flusher. It sends the data compressed so far, and
+// then the buffered data, down the transport.
+func (rw *responseWriter) Flush() {
+	if rw.wfl != nil {
+		rw.wfl.Flush()
 	}
-
-	if compressor, ok := rw.wfl.(compressor); ok {
-		compressor.Flush()
-		return
-	}
-
-	if flusher, ok := rw.wfl.(http.Flusher); ok {
+	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
 		flusher.Flush()
-		return
 	}
 }
 
 // Write will compress data in the format specified in the Content-Encoding
-// header of the embedded http.ResponseWriter.
+// header of the embedded http.ResponseWriter. The same compressor is used for
+// all the writes of a response, which allows the body to be streamed.
 func (rw *responseWriter) Write(b []byte) (int, error) {
-	n, err := compress(rw.ResponseWriter.Header().Get("Content-Encoding"), rw.ResponseWriter, b)
-	if err == errUnknownCompressionFormat {
+	if rw.wfl == nil && !rw.uncompress && len(b) > 0 {
+		wfl, err := getCompressor(rw.ResponseWriter.Header().Get("Content-Encoding"), rw.ResponseWriter)
+		if err != nil {
+			rw.uncompress = true
+		}
+		rw.wfl = wfl
+	}
+	if rw.wfl == nil {
 		return rw.ResponseWriter.Write(b)
 	}
-	return n, err
+	return rw.wfl.Write(b)
+}
+
+// close terminates the compressed body, if any. It must be called once the
+// response has been written.
+func (rw *responseWriter) close() {
+	if rw.wfl != nil {
+		rw.wfl.Close()
+		putCompressor(rw.wfl)
+		rw.wfl = nil
+	}
 }
 
 // newResponseWriter returns an enhanced implementation of http.ResponseWriter.
@@ -285,49 +568,395 @@ func newResponseWriter(w http.ResponseWriter) *responseWriter {
 	return &responseWriter{ResponseWriter: w}
 }
 
-const varsKey = "__rst__vars"
+// contextKey is the type of the keys of the values stored by rst in the
+// context of requests.
+type contextKey int
 
-func getVars(r *http.Request) (vars RouteVars) {
-	if v := context.Get(r, varsKey); v != nil {
-		vars = v.(RouteVars)
-	}
+const (
+	varsKey contextKey = iota
+	routeKey
+	codecsKey
+	timeoutKey
+	conditionsKey
+	autoETagKey
+	jobsKey
+	paginateKey
+)
+
+// withValue returns a shallow copy of r with a context carrying val for key.
+func withValue(r *http.Request, key contextKey, val interface{}) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), key, val))
+}
+
+// Vars returns the variables extracted by the router from the URL of r, or nil
+// if r was not routed by a Mux.
+func Vars(r *http.Request) RouteVars {
+	vars, _ := r.Context().Value(varsKey).(RouteVars)
 	return vars
 }
-func setVars(r *http.Request, vars RouteVars) {
-	context.Set(r, varsKey, vars)
+
+// CurrentRoute returns the route matched by r, or nil if r was not routed by a
+// Mux.
+func CurrentRoute(r *http.Request) *Route {
+	route, _ := r.Context().Value(routeKey).(*Route)
+	return route
+}
+
+// requestURL returns the absolute URL of r. The scheme is https if r was
+// received over TLS, or if a proxy says so in the X-Forwarded-Proto header.
+func requestURL(r *http.Request) *url.URL {
+	u := *r.URL
+	u.Host = r.Host
+	u.Scheme = "http"
+	if r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https") {
+		u.Scheme = "https"
+	}
+	return &u
 }
-func delVars(r *http.Request) {
-	context.Clear(r)
+
+// resolveLocation resolves location against the URL of r if it is relative.
+// It is returned unchanged if it is absolute or can't be parsed.
+func resolveLocation(r *http.Request, location string) string {
+	u, err := url.Parse(location)
+	if err != nil || u.IsAbs() {
+		return location
+	}
+	return requestURL(r).ResolveReference(u).String()
 }
 
 // Mux is an HTTP request multiplexer. It matches the URL of each incoming
 // requests against a list of registered REST endpoints.
 type Mux struct {
-	Debug     bool // Set to true to display stack traces and debug info in errors.
-	Logger    *log.Logger
-	header    http.Header
-	ac        *AccessControlResponse
-	m         *gorillaMux.Router
-	endpoints map[string]mapEndpoint
+	Debug   bool          // Set to true to display stack traces and debug info in errors. Applies to groups as well. See SetDebug.
+	Codecs  *Codecs       // Codecs used to encode and decode resources. DefaultCodecs if nil.
+	Timeout time.Duration // Maximum duration of context-aware endpoints. Inherited by groups if 0.
+	Logger  *log.Logger   // Logger of the panics recovered by the mux. Inherited by groups if nil.
+
+	// Set to true to respond with 428 PRECONDITION REQUIRED to PUT, PATCH and
+	// DELETE requests without conditional headers, when their endpoint is a
+	// Loader of a resource with validators. Applies to groups as well. See
+	// SetRequireConditions.
+	RequireConditions bool
+
+	// Set to true to identify resources returning an empty ETag with a hash of
+	// their encoded representation. Applies to groups as well. See
+	// SetAutoETag.
+	AutoETag bool
+
+	// Set to true to add a Link header with the ranges of the first,
+	// previous, next and last pages to the partial responses of Rangers in
+	// units other than bytes. Applies to groups as well. See
+	// SetPaginateRanges.
+	PaginateRanges bool
+
+	// Maximum number of ranges served in a multipart/byteranges response.
+	// Requests for more ranges are served in full. DefaultMaxRanges if 0.
+	// Inherited by groups if 0.
+	MaxRanges int
+
+	// Maximum delay during which requests starting jobs wait for them to
+	// finish when clients prefer to. DefaultMaxWait if 0. See HandleJobs.
+	MaxWait time.Duration
+
+	// Duration during which the status of finished jobs remains available.
+	// DefaultJobRetention if 0. See HandleJobs.
+	JobRetention time.Duration
+
+	// Delay sent in the Retry-After header of the status of unfinished jobs,
+	// rounded up to the second. DefaultJobPollInterval if 0. See HandleJobs.
+	JobPollInterval time.Duration
+
+	// Interval of the comments sent to keep idle event streams open.
+	// DefaultEventHeartbeat if 0, and disabled if negative. Inherited by groups
+	// if 0.
+	EventHeartbeat time.Duration
+
+	// Minimal interval at which the items of a Collection are flushed to the
+	// client. DefaultCollectionFlushInterval if 0, and after every item if
+	// negative. Inherited by groups if 0.
+	CollectionFlushInterval time.Duration
+
+	header     http.Header
+	ac         *AccessControlResponse
+	acSet      bool     // true once SetCORSPolicy has been called.
+	flagsSet   muxFlags // Flags set explicitly with SetDebug, SetAutoETag, etc.
+	m          *gorillaMux.Router
+	middleware []Middleware
+	endpoints  map[string]*Route // Routes registered with Get, Post, etc.
+	routes     []*Route          // All the routes of the mux and its groups, in order of registration. Root only.
+	parent     *Mux              // Mux this one was grouped from, if any.
+	prefix     string            // Prefix of all the patterns registered in this mux.
+	jobs       *jobRegistry      // Jobs started by the endpoints of this mux, if handled.
 }
 
+// defaultLogger is the logger of the panics recovered by a mux without a
+// Logger.
+var defaultLogger = log.New(os.Stdout, "rst: ", log.LstdFlags)
+
 // NewMux initializes a new REST multiplexer.
 func NewMux() *Mux {
 	s := &Mux{
-		Logger:    log.New(os.Stdout, "rst: ", log.LstdFlags),
+		Logger:    defaultLogger,
 		header:    make(http.Header),
 		m:         gorillaMux.NewRouter(),
-		endpoints: make(map[string]mapEndpoint),
+		endpoints: make(map[string]*Route),
 	}
 	return s
 }
 
+/*
+Group returns a new mux sharing the router of s, in which all the patterns are
+registered under the given prefix.
+
+The group inherits the headers, CORS policy, codecs and middleware of s, and can
+override them. Its flags, such as Debug, and its Logger are looked up in s when
+serving requests, unless they are set in the group. Flags set to true in s are
+turned off in the group with SetDebug(false), SetAutoETag(false), etc.
+
+	admin := mux.Group("/admin")
+	admin.Header().Set("Cache-Control", "private")
+	admin.SetCORSPolicy(nil)
+	admin.Use(auth)
+	admin.HandleEndpoint("/users/{id}", &UserEP{}) // matches /admin/users/{id}
+
+Requests matching the routes of a group are served from the mux it was created
+from as well. When a group is served directly, only its own routes and those
+of its descendants are matched.
+*/
+func (s *Mux) Group(prefix string) *Mux {
+	return &Mux{
+		header:    make(http.Header),
+		m:         s.m,
+		endpoints: make(map[string]*Route),
+		parent:    s,
+		prefix:    s.prefix + prefix,
+	}
+}
+
+// lineage returns the list of muxes from s to m, or nil if m was not grouped
+// from s.
+func (s *Mux) lineage(m *Mux) []*Mux {
+	var muxes []*Mux
+	for ; m != nil; m = m.parent {
+		muxes = append([]*Mux{m}, muxes...)
+		if m == s {
+			return muxes
+		}
+	}
+	return nil
+}
+
+// root returns the mux s was grouped from, directly or not, or s itself.
+func (s *Mux) root() *Mux {
+	for s.parent != nil {
+		s = s.parent
+	}
+	return s
+}
+
+// codecs returns the codecs registered in s, or in the closest mux it was
+// grouped from.
+func (s *Mux) codecs() *Codecs {
+	for m := s; m != nil; m = m.parent {
+		if m.Codecs != nil {
+			return m.Codecs
+		}
+	}
+	return nil
+}
+
+// jobRegistry returns the registry of the jobs handled by s, or by the closest
+// mux it was grouped from.
+func (s *Mux) jobRegistry() *jobRegistry {
+	for m := s; m != nil; m = m.parent {
+		if m.jobs != nil {
+			return m.jobs
+		}
+	}
+	return nil
+}
+
+// muxFlags is a set of the boolean settings of a mux.
+type muxFlags uint8
+
+// Boolean settings of a mux.
+const (
+	debugFlag muxFlags = 1 << iota
+	requireConditionsFlag
+	autoETagFlag
+	paginateRangesFlag
+)
+
+// value returns the field of m holding flag.
+func (flag muxFlags) value(m *Mux) *bool {
+	switch flag {
+	case debugFlag:
+		return &m.Debug
+	case requireConditionsFlag:
+		return &m.RequireConditions
+	case autoETagFlag:
+		return &m.AutoETag
+	}
+	return &m.PaginateRanges
+}
+
+// setFlag sets flag to value in s, overriding the setting of the muxes it was
+// grouped from.
+func (s *Mux) setFlag(flag muxFlags, value bool) {
+	*flag.value(s) = value
+	s.flagsSet |= flag
+}
+
+// flag returns the value of flag in s, or in the closest mux it was grouped
+// from in which it is either true or set explicitly.
+func (s *Mux) flag(flag muxFlags) bool {
+	for m := s; m != nil; m = m.parent {
+		if value := *flag.value(m); value || m.flagsSet&flag != 0 {
+			return value
+		}
+	}
+	return false
+}
+
+// SetDebug sets the Debug flag of s. Unlike the field, which groups inherit
+// when it's true, it overrides the flag of the mux s was grouped from.
+//
+//	mux.Debug = true
+//	mux.Group("/public").SetDebug(false)
+func (s *Mux) SetDebug(debug bool) {
+	s.setFlag(debugFlag, debug)
+}
+
+// SetRequireConditions sets the RequireConditions flag of s, overriding the
+// flag of the mux s was grouped from. See SetDebug.
+func (s *Mux) SetRequireConditions(require bool) {
+	s.setFlag(requireConditionsFlag, require)
+}
+
+// SetAutoETag sets the AutoETag flag of s, overriding the flag of the mux s
+// was grouped from. See SetDebug.
+func (s *Mux) SetAutoETag(autoETag bool) {
+	s.setFlag(autoETagFlag, autoETag)
+}
+
+// SetPaginateRanges sets the PaginateRanges flag of s, overriding the flag of
+// the mux s was grouped from. See SetDebug.
+func (s *Mux) SetPaginateRanges(paginate bool) {
+	s.setFlag(paginateRangesFlag, paginate)
+}
+
+// debug returns true if Debug applies to s.
+func (s *Mux) debug() bool {
+	return s.flag(debugFlag)
+}
+
+// logger returns the logger set in s, or in the closest mux it was grouped
+// from.
+func (s *Mux) logger() *log.Logger {
+	for m := s; m != nil; m = m.parent {
+		if m.Logger != nil {
+			return m.Logger
+		}
+	}
+	return defaultLogger
+}
+
+// timeout returns the timeout set in s, or in the closest mux it was grouped
+// from.
+func (s *Mux) timeout() time.Duration {
+	for m := s; m != nil; m = m.parent {
+		if m.Timeout > 0 {
+			return m.Timeout
+		}
+	}
+	return 0
+}
+
+// eventHeartbeat returns the heartbeat interval set in s, or in the closest mux
+// it was grouped from, or 0 if heartbeats are disabled.
+func (s *Mux) eventHeartbeat() time.Duration {
+	for m := s; m != nil; m = m.parent {
+		if m.EventHeartbeat < 0 {
+			return 0
+		}
+		if m.EventHeartbeat > 0 {
+			return m.EventHeartbeat
+		}
+	}
+	return DefaultEventHeartbeat
+}
+
+// collectionFlushInterval returns the flush interval of collections set in s,
+// or in the closest mux it was grouped from.
+func (s *Mux) collectionFlushInterval() time.Duration {
+	for m := s; m != nil; m = m.parent {
+		if m.CollectionFlushInterval < 0 {
+			return 0
+		}
+		if m.CollectionFlushInterval > 0 {
+			return m.CollectionFlushInterval
+		}
+	}
+	return DefaultCollectionFlushInterval
+}
+
+// conditionsRequired returns true if RequireConditions applies to s.
+func (s *Mux) conditionsRequired() bool {
+	return s.flag(requireConditionsFlag)
+}
+
+// autoETag returns true if AutoETag applies to s.
+func (s *Mux) autoETag() bool {
+	return s.flag(autoETagFlag)
+}
+
+// maxRanges returns the maximum number of ranges set in s, or in the closest
+// mux it was grouped from.
+func (s *Mux) maxRanges() int {
+	for m := s; m != nil; m = m.parent {
+		if m.MaxRanges > 0 {
+			return m.MaxRanges
+		}
+	}
+	return DefaultMaxRanges
+}
+
+// paginateRanges returns true if PaginateRanges applies to s.
+func (s *Mux) paginateRanges() bool {
+	return s.flag(paginateRangesFlag)
+}
+
+// corsPolicy returns the CORS policy set in s, or in the closest mux it was
+// grouped from.
+func (s *Mux) corsPolicy() *AccessControlResponse {
+	for m := s; m != nil; m = m.parent {
+		if m.acSet {
+			return m.ac
+		}
+	}
+	return nil
+}
+
 // Header contains the headers that will automatically be set in all responses
-// served from this mux.
+// served from this mux. In a group, they override the headers of the mux it was
+// grouped from.
 func (s *Mux) Header() http.Header {
 	return s.header
 }
 
+// writeHeader sets the custom headers of s in w.
+func (s *Mux) writeHeader(w http.ResponseWriter) {
+	for key, values := range s.header {
+		for i, value := range values {
+			if i == 0 {
+				w.Header().Set(key, value)
+			} else {
+				w.Header().Add(key, value)
+			}
+		}
+	}
+}
+
 /*
 SetCORSPolicy sets the access control parameters that will be used to write
 CORS related headers. By default, CORS support is disabled.
@@ -340,30 +969,39 @@ custom defined AccessControlResponse struct. A nil value will disable support.
 */
 func (s *Mux) SetCORSPolicy(ac *AccessControlResponse) {
 	s.ac = ac
+	s.acSet = true
 }
 
 func (s *Mux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// owner is the mux in which the matched route was registered. Its settings
+	// apply once the request has been routed.
+	owner := s
+
+	if codecs := s.codecs(); codecs != nil {
+		r = withValue(r, codecsKey, codecs)
+	}
+
 	defer func() {
 		if err := recover(); err != nil {
+			if err == http.ErrAbortHandler {
+				// Aborted responses are left to the server.
+				panic(err)
+			}
 			reason := fmt.Sprintf("%s", err) // Stringer interface
-			if !s.Debug {
+			debug := owner.debug()
+			if !debug {
 				t := InternalServerError(reason, "", true)
-				s.Logger.Println(t.String())
+				owner.logger().Println(t.String())
 				reason = http.StatusText(http.StatusInternalServerError)
 			}
-			InternalServerError(reason, "", s.Debug).ServeHTTP(w, r)
+			InternalServerError(reason, "", debug).ServeHTTP(w, r)
 		}
 	}()
 
 	// Custom headers are written no matter what.
-	for key, values := range s.header {
-		for i, value := range values {
-			if i == 0 {
-				w.Header().Set(key, value)
-			} else {
-				w.Header().Add(key, value)
-			}
-		}
+	lineage := s.root().lineage(s)
+	for _, m := range lineage {
+		m.writeHeader(w)
 	}
 
 	match := s.match(r)
@@ -372,86 +1010,327 @@ func (s *Mux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	setVars(r, RouteVars(match.Vars))
-	defer delVars(r)
+	route := match.Handler.(*Route)
+	descendants := s.lineage(route.mux)
+	if descendants == nil {
+		NotFound().ServeHTTP(w, r)
+		return
+	}
+	owner = route.mux
+	for _, m := range descendants[1:] {
+		m.writeHeader(w)
+		lineage = append(lineage, m)
+	}
+	if codecs := owner.codecs(); codecs != nil {
+		r = withValue(r, codecsKey, codecs)
+	}
+
+	vars := RouteVars(match.Vars)
+	r = withValue(r, varsKey, vars)
+	r = withValue(r, routeKey, route)
+	timeout := owner.timeout()
+	if route.timeout != nil {
+		timeout = *route.timeout
+	}
+	if timeout > 0 {
+		r = withValue(r, timeoutKey, timeout)
+	}
+	if owner.conditionsRequired() {
+		r = withValue(r, conditionsKey, true)
+	}
+	if owner.autoETag() {
+		r = withValue(r, autoETagKey, true)
+	}
+	if owner.paginateRanges() {
+		r = withValue(r, paginateKey, true)
+	}
+	if jobs := owner.jobRegistry(); jobs != nil {
+		r = withValue(r, jobsKey, jobs)
+	}
+
+	if ac := owner.corsPolicy(); ac != nil {
+		newAccessControlHandler(route.Endpoint(), ac).ServeHTTP(w, r)
+	}
 
-	if s.ac != nil {
-		if handler, valid := match.Handler.(*endpointHandler); valid {
-			newAccessControlHandler(handler.endpoint, s.ac).ServeHTTP(w, r)
-		} else {
-			newAccessControlHandler(nil, s.ac).ServeHTTP(w, r)
+	// Middleware of the muxes run from the outermost group to the route.
+	handler := route.handler
+	for i := len(route.middleware) - 1; i >= 0; i-- {
+		handler = route.middleware[i].wrap(route, vars, handler)
+	}
+	for i := len(lineage) - 1; i >= 0; i-- {
+		for j := len(lineage[i].middleware) - 1; j >= 0; j-- {
+			handler = lineage[i].middleware[j].wrap(route, vars, handler)
 		}
 	}
-	match.Handler.ServeHTTP(newResponseWriter(w), r)
+	// The compressed body isn't terminated when the handler panics, so that
+	// aborted responses aren't mistaken for complete ones.
+	rw := newResponseWriter(w)
+	handler.ServeHTTP(rw, r)
+	rw.close()
+}
+
+// Use appends middleware to the chain run on every request matching a route of
+// this mux, or of the groups created from it.
+func (s *Mux) Use(middleware ...Middleware) {
+	s.middleware = append(s.middleware, middleware...)
 }
 
 // HandleEndpoint registers the endpoint for the given pattern.
 // It's a shorthand for:
 // 	s.Handle(pattern, EndpointHandler(endpoint))
-func (s *Mux) HandleEndpoint(pattern string, endpoint Endpoint) {
-	s.Handle(pattern, EndpointHandler(endpoint))
+func (s *Mux) HandleEndpoint(pattern string, endpoint Endpoint) *Route {
+	return s.Handle(pattern, EndpointHandler(endpoint))
 }
 
 // Handle registers the handler function for the given pattern.
-func (s *Mux) Handle(pattern string, handler http.Handler) {
-	s.m.Handle(pattern, handler)
+func (s *Mux) Handle(pattern string, handler http.Handler) *Route {
+	route := &Route{pattern: s.prefix + pattern, handler: handler, mux: s}
+	route.route = s.m.Handle(route.pattern, route)
+	root := s.root()
+	root.routes = append(root.routes, route)
+	return route
+}
+
+// Routes returns the routes registered in s and in the groups created from it,
+// in order of registration.
+func (s *Mux) Routes() []*Route {
+	var routes []*Route
+	for _, route := range s.root().routes {
+		if s.lineage(route.mux) != nil {
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
+
+/*
+URL returns the path of the route registered under name in s, or in any mux
+sharing its router, built with the given variables.
+
+	mux.Get("/people/{id:\\d+}", getPerson).Name("person")
+	u, err := mux.URL("person", rst.RouteVars{"id": "42"}) // /people/42
+
+An error is returned if no route was registered under name, or if vars don't
+match the pattern of the route.
+*/
+func (s *Mux) URL(name string, vars RouteVars) (*url.URL, error) {
+	route := s.m.Get(name)
+	if route == nil {
+		return nil, fmt.Errorf("no route named %q", name)
+	}
+	var pairs []string
+	for key, value := range vars {
+		pairs = append(pairs, key, value)
+	}
+	return route.URLPath(pairs...)
+}
+
+// AbsoluteURL is like URL, but returns an absolute URL resolved against the
+// scheme and host of r.
+func (s *Mux) AbsoluteURL(r *http.Request, name string, vars RouteVars) (*url.URL, error) {
+	u, err := s.URL(name, vars)
+	if err != nil {
+		return nil, err
+	}
+	return requestURL(r).ResolveReference(u), nil
 }
 
 // Handle registers the handler function for the given pattern.
-func (s *Mux) handleMethod(pattern string, method string, handler http.Handler) {
-	if _, ok := s.endpoints[pattern]; !ok {
-		s.endpoints[pattern] = make(mapEndpoint)
-		s.m.Handle(pattern, EndpointHandler(s.endpoints[pattern]))
+func (s *Mux) handleMethod(pattern string, method string, handler http.Handler) *Route {
+	route, ok := s.endpoints[pattern]
+	if !ok {
+		route = s.Handle(pattern, EndpointHandler(make(mapEndpoint)))
+		s.endpoints[pattern] = route
 	}
-	s.endpoints[pattern][method] = handler
+	route.Endpoint().(mapEndpoint)[method] = handler
+	return route
 }
 
 // Get registers handler for GET requests on the given pattern.
-func (s *Mux) Get(pattern string, handler GetFunc) {
-	s.handleMethod(pattern, Get, handler)
+func (s *Mux) Get(pattern string, handler GetFunc) *Route {
+	return s.handleMethod(pattern, Get, handler)
 }
 
 // Post registers handler for POST requests on the given pattern.
-func (s *Mux) Post(pattern string, handler PostFunc) {
-	s.handleMethod(pattern, Post, handler)
+func (s *Mux) Post(pattern string, handler PostFunc) *Route {
+	return s.handleMethod(pattern, Post, handler)
 }
 
 // Put registers handler for PUT requests on the given pattern.
-func (s *Mux) Put(pattern string, handler PutFunc) {
-	s.handleMethod(pattern, Put, handler)
+func (s *Mux) Put(pattern string, handler PutFunc) *Route {
+	return s.handleMethod(pattern, Put, handler)
 }
 
 // Patch registers handler for PATCH requests on the given pattern.
-func (s *Mux) Patch(pattern string, handler PatchFunc) {
-	s.handleMethod(pattern, Put, handler)
+func (s *Mux) Patch(pattern string, handler PatchFunc) *Route {
+	return s.handleMethod(pattern, Patch, handler)
 }
 
 // Delete registers handler for DELETE requests on the given pattern.
-func (s *Mux) Delete(pattern string, handler DeleteFunc) {
-	s.handleMethod(pattern, Delete, handler)
+func (s *Mux) Delete(pattern string, handler DeleteFunc) *Route {
+	return s.handleMethod(pattern, Delete, handler)
+}
+
+// GetContext registers handler for GET requests on the given pattern.
+func (s *Mux) GetContext(pattern string, handler GetContextFunc) *Route {
+	return s.handleMethod(pattern, Get, handler)
+}
+
+// PostContext registers handler for POST requests on the given pattern.
+func (s *Mux) PostContext(pattern string, handler PostContextFunc) *Route {
+	return s.handleMethod(pattern, Post, handler)
+}
+
+// PutContext registers handler for PUT requests on the given pattern.
+func (s *Mux) PutContext(pattern string, handler PutContextFunc) *Route {
+	return s.handleMethod(pattern, Put, handler)
+}
+
+// PatchContext registers handler for PATCH requests on the given pattern.
+func (s *Mux) PatchContext(pattern string, handler PatchContextFunc) *Route {
+	return s.handleMethod(pattern, Patch, handler)
+}
+
+// DeleteContext registers handler for DELETE requests on the given pattern.
+func (s *Mux) DeleteContext(pattern string, handler DeleteContextFunc) *Route {
+	return s.handleMethod(pattern, Delete, handler)
+}
+
+// Events registers handler for GET requests accepting text/event-stream on the
+// given pattern. Other GET requests are served by the handler registered with
+// Get, if any.
+func (s *Mux) Events(pattern string, handler EventSourceFunc) *Route {
+	return s.handleMethod(pattern, eventsMethod, handler)
+}
+
+// HandleJobs registers the status resources of the jobs returned by the
+// endpoints of s and of its groups under prefix. The status of a job is served
+// at prefix/{id} until s.JobRetention after it has finished, and redirects to its
+// result once it has succeeded. DELETE requests cancel the job and remove it.
+//
+//	mux.HandleJobs("/jobs")
+func (s *Mux) HandleJobs(prefix string) *Route {
+	s.jobs = &jobRegistry{mux: s, prefix: s.prefix + prefix, jobs: make(map[string]*Job)}
+	return s.HandleEndpoint(prefix+"/{id}", &jobsEndpoint{s.jobs})
+}
+
+// Route is a pattern registered in a Mux, along with the handler serving the
+// requests matching it.
+type Route struct {
+	pattern    string
+	handler    http.Handler
+	middleware []Middleware
+	mux        *Mux
+	route      *gorillaMux.Route
+	timeout    *time.Duration // Nil if unset.
+}
+
+// Pattern returns the URL pattern of the route, including the prefix of the
+// group it was registered in.
+func (rt *Route) Pattern() string {
+	return rt.pattern
+}
+
+// Name sets the name of the route, which can then be used to build its URL with
+// Mux.URL. Names are shared by all the groups of a mux.
+func (rt *Route) Name(name string) *Route {
+	rt.route.Name(name)
+	return rt
+}
+
+// GetName returns the name of the route, if any.
+func (rt *Route) GetName() string {
+	return rt.route.GetName()
+}
+
+// Endpoint returns the endpoint registered with the route, or nil if the route
+// was registered with an http.Handler that is not an endpoint.
+func (rt *Route) Endpoint() Endpoint {
+	if handler, ok := rt.handler.(*endpointHandler); ok {
+		return handler.endpoint
+	}
+	return nil
+}
+
+// Methods returns the HTTP methods allowed by the endpoint of the route, or nil
+// if the route was not registered with an endpoint.
+func (rt *Route) Methods() []string {
+	if endpoint := rt.Endpoint(); endpoint != nil {
+		return AllowedMethods(endpoint)
+	}
+	return nil
+}
+
+// Codecs returns the codecs used to encode and decode the resources served by
+// the route.
+func (rt *Route) Codecs() *Codecs {
+	if codecs := rt.mux.codecs(); codecs != nil {
+		return codecs
+	}
+	return DefaultCodecs
+}
+
+// Timeout sets the maximum duration of the context-aware endpoints of the
+// route, overriding the Timeout of the mux. A duration of 0 disables the
+// timeout of the mux for the route.
+func (rt *Route) Timeout(d time.Duration) *Route {
+	rt.timeout = &d
+	return rt
+}
+
+// Use appends middleware to the chain run on requests matching this route,
+// after the middleware of the mux.
+func (rt *Route) Use(middleware ...Middleware) *Route {
+	rt.middleware = append(rt.middleware, middleware...)
+	return rt
+}
+
+// ServeHTTP implements the http.Handler interface. Middleware are not run when
+// the route is served directly.
+func (rt *Route) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rt.handler.ServeHTTP(w, r)
 }
 
-// match returns the route
+// match returns the route matching r in s or in its groups, or nil.
+//
+// The router is shared by all the groups of a mux, so the routes of a group
+// served directly are matched one by one, in order of registration, to skip
+// the routes of its siblings.
 func (s *Mux) match(r *http.Request) *gorillaMux.RouteMatch {
 	var match gorillaMux.RouteMatch
-	if !s.m.Match(r, &match) {
-		return nil
+	if s.parent == nil {
+		if !s.m.Match(r, &match) {
+			return nil
+		}
+		return &match
 	}
-	return &match
+	for _, route := range s.Routes() {
+		if route.route.Match(r, &match) {
+			return &match
+		}
+	}
+	return nil
 }
 
 // mapEndpoint defines HTTP handlers for a given set of
 type mapEndpoint map[string]http.Handler
 
+// eventsMethod is the key of the handler of the GET requests accepting
+// text/event-stream in a mapEndpoint.
+const eventsMethod = "EVENTS"
+
 // allowedMethods returns an array containing the HTTP methods supported by
 // this endpoint.
 func (e mapEndpoint) allowedMethods() []string {
 	var methods []string
-	for method := range e {
-		methods = append(methods, method)
-	}
-	if _, ok := e[Get]; ok {
-		methods = append(methods, Head)
+	for _, method := range supportedMethods {
+		_, ok := e[method]
+		if (method == Head || method == Get) && e[eventsMethod] != nil {
+			ok = true
+		}
+		if ok || (method == Head && e[Get] != nil) {
+			methods = append(methods, method)
+		}
 	}
 	return methods
 }
@@ -465,11 +1344,25 @@ func (e mapEndpoint) validateMethod(r *http.Request) error {
 	return nil
 }
 
+// methodHandler implements the methodRouter interface.
+func (e mapEndpoint) methodHandler(method string, header http.Header) http.Handler {
+	if method == Head {
+		method = Get
+	}
+	if events := e[eventsMethod]; method == Get && events != nil && (acceptsEvents(header) || e[Get] == nil) {
+		return events
+	}
+	return e[method]
+}
+
 // Get implements the Getter interface.
 func (e mapEndpoint) Get(vars RouteVars, r *http.Request) (Resource, error) {
 	if err := e.validateMethod(r); err != nil {
 		return nil, err
 	}
+	if fn, ok := e[r.Method].(GetContextFunc); ok {
+		return fn(r.Context(), vars, r)
+	}
 	fn := e[r.Method].(GetFunc)
 	return fn(vars, r)
 }
@@ -479,6 +1372,9 @@ func (e mapEndpoint) Post(vars RouteVars, r *http.Request) (Resource, string, er
 	if err := e.validateMethod(r); err != nil {
 		return nil, "", err
 	}
+	if fn, ok := e[r.Method].(PostContextFunc); ok {
+		return fn(r.Context(), vars, r)
+	}
 	fn := e[r.Method].(PostFunc)
 	return fn(vars, r)
 }
@@ -488,6 +1384,9 @@ func (e mapEndpoint) Put(vars RouteVars, r *http.Request) (Resource, error) {
 	if err := e.validateMethod(r); err != nil {
 		return nil, err
 	}
+	if fn, ok := e[r.Method].(PutContextFunc); ok {
+		return fn(r.Context(), vars, r)
+	}
 	fn := e[r.Method].(PutFunc)
 	return fn(vars, r)
 }
@@ -497,6 +1396,9 @@ func (e mapEndpoint) Patch(vars RouteVars, r *http.Request) (Resource, error) {
 	if err := e.validateMethod(r); err != nil {
 		return nil, err
 	}
+	if fn, ok := e[r.Method].(PatchContextFunc); ok {
+		return fn(r.Context(), vars, r)
+	}
 	fn := e[r.Method].(PatchFunc)
 	return fn(vars, r)
 }
@@ -506,6 +1408,9 @@ func (e mapEndpoint) Delete(vars RouteVars, r *http.Request) error {
 	if err := e.validateMethod(r); err != nil {
 		return nil
 	}
+	if fn, ok := e[r.Method].(DeleteContextFunc); ok {
+		return fn(r.Context(), vars, r)
+	}
 	fn := e[r.Method].(DeleteFunc)
 	return fn(vars, r)
 }
@@ -518,6 +1423,7 @@ type Envelope struct {
 	etag         string
 	ttl          time.Duration
 	header       http.Header
+	cacheControl *CacheControl
 }
 
 // Header returns the list of headers that will be added to the ResponseWriter.
@@ -545,6 +1451,18 @@ func (e *Envelope) ETag() string {
 	return e.etag
 }
 
+// CacheControl implements the rst.CacheController interface. It returns nil
+// unless SetCacheControl was called.
+func (e *Envelope) CacheControl() *CacheControl {
+	return e.cacheControl
+}
+
+// SetCacheControl sets the policy written in the Cache-Control header of the
+// responses serving e, instead of one derived from its TTL.
+func (e *Envelope) SetCacheControl(cc *CacheControl) {
+	e.cacheControl = cc
+}
+
 // MarshalRST marshals projection.
 func (e *Envelope) MarshalRST(r *http.Request) (string, []byte, error) {
 	return Marshal(e.projection, r)
@@ -558,7 +1476,6 @@ func (e *Envelope) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set("Content-Type", contentType)
 	if e.header != nil {
 		for key, values := range e.header {
 			for _, value := range values {
@@ -566,32 +1483,7 @@ func (e *Envelope) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-
-	if compression := getCompressionFormat(b, r); compression != "" {
-		w.Header().Set("Content-Encoding", compression)
-	}
-
-	if strings.ToUpper(r.Method) == Post {
-		w.WriteHeader(http.StatusCreated)
-		w.Write(b)
-		return
-	}
-
-	if len(b) == 0 {
-		w.WriteHeader(http.StatusNoContent)
-		return
-	}
-
-	if w.Header().Get("Content-Range") != "" {
-		w.WriteHeader(http.StatusPartialContent)
-	} else {
-		w.WriteHeader(http.StatusOK)
-	}
-
-	if strings.ToUpper(r.Method) == Head {
-		return
-	}
-	w.Write(b)
+	writeRepresentation(e, contentType, b, w, r)
 }
 
 // NewEnvelope returns a struct that marshals projection when used as an
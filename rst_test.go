@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -203,6 +205,17 @@ func TestMuxMethodHandlers(t *testing.T) {
 	testMux.Delete("/employers/{name}", func(vars RouteVars, r *http.Request) error {
 		return nil
 	})
+
+	testMux.Patch("/muxPatchHandler", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return nil, nil
+	})
+	rr = newRequestResponse(Options, testServerAddr+"/muxPatchHandler", nil, nil)
+	if err := rr.TestHeaderContains("Allow", Patch); err != nil {
+		t.Fatal(err)
+	}
+	if err := rr.TestHeaderContains("Allow", Put); err == nil {
+		t.Fatal("Put not expected to be found in headers")
+	}
 }
 
 func TestEnvelope(t *testing.T) {
@@ -213,7 +226,7 @@ func TestEnvelope(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		if err := rr.TestHeader("ETag", envelopeETag); err != nil {
+		if err := rr.TestHeader("ETag", `"`+envelopeETag+`"`); err != nil {
 			t.Fatal(err)
 		}
 
@@ -242,3 +255,223 @@ func TestEnvelope(t *testing.T) {
 	test("application/json", bytes.NewReader(b))
 	test("text/plain", bytes.NewReader([]byte(envelopeTextProjection)))
 }
+
+func TestMuxGroup(t *testing.T) {
+	var calls []string
+	var trace = func(name string) Middleware {
+		return func(route *Route, vars RouteVars, w http.ResponseWriter, r *http.Request, next http.Handler) error {
+			calls = append(calls, name)
+			next.ServeHTTP(w, r)
+			return nil
+		}
+	}
+
+	mux := NewMux()
+	mux.Header().Set("X-Powered-By", "rst")
+	mux.Header().Set("Cache-Control", "public")
+	mux.SetCORSPolicy(PermissiveAccessControl)
+	mux.Use(trace("mux"))
+	mux.HandleEndpoint("/people/{id}", &personResource{})
+
+	admin := mux.Group("/admin")
+	admin.Header().Set("Cache-Control", "private")
+	admin.SetCORSPolicy(nil)
+	admin.Use(trace("admin"))
+	admin.HandleEndpoint("/people/{id}", &personResource{})
+
+	public := mux.Group("/public").Group("/v1")
+	public.Codecs = NewCodecs(XMLCodec)
+	public.Debug = true
+	public.HandleEndpoint("/people/{id}", &personResource{})
+	public.HandleEndpoint("/panic", &panicEndpoint{})
+
+	var test = func(s *Mux, url string, code int, expectedCalls string) *httptest.ResponseRecorder {
+		calls = nil
+		r, _ := http.NewRequest(Get, url, nil)
+		r.Header.Set("Origin", "example.com")
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, r)
+		if w.Code != code {
+			t.Fatal(url, "Got:", w.Code, "Wanted:", code)
+		}
+		if got := strings.Join(calls, ","); got != expectedCalls {
+			t.Fatal(url, "Got:", got, "Wanted:", expectedCalls)
+		}
+		if w.Header().Get("X-Powered-By") != "rst" {
+			t.Fatal(url, "missing inherited header")
+		}
+		return w
+	}
+
+	id := testPeople[3].ID
+	w := test(mux, "http://www.example.com/people/"+id, http.StatusOK, "mux")
+	if w.Header().Get("Cache-Control") != "public" || w.Header().Get("Access-Control-Allow-Origin") == "" {
+		t.Fatal("unexpected headers:", w.Header())
+	}
+
+	w = test(mux, "http://www.example.com/admin/people/"+id, http.StatusOK, "mux,admin")
+	if w.Header().Get("Cache-Control") != "private" || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Fatal("unexpected headers:", w.Header())
+	}
+
+	w = test(mux, "http://www.example.com/public/v1/people/"+id, http.StatusOK, "mux")
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), "application/xml") || w.Header().Get("Access-Control-Allow-Origin") == "" {
+		t.Fatal("unexpected headers:", w.Header())
+	}
+
+	// Debug is specific to the group.
+	w = test(mux, "http://www.example.com/public/v1/panic", http.StatusInternalServerError, "mux")
+	if !strings.Contains(w.Body.String(), "provoked panic") {
+		t.Fatal("expected debug info in the response")
+	}
+
+	// Serving a group directly only matches its own routes.
+	test(admin, "http://www.example.com/admin/people/"+id, http.StatusOK, "mux,admin")
+	test(admin, "http://www.example.com/people/"+id, http.StatusNotFound, "")
+
+	// Routes of a sibling group matching first are skipped.
+	mux.Group("/v2").HandleEndpoint("/{kind}/{id}", &personResource{})
+	v2 := mux.Group("/v2")
+	v2.HandleEndpoint("/people/{id}", &personResource{})
+	v2.HandleEndpoint("/panic", &panicEndpoint{})
+	test(v2, "http://www.example.com/v2/people/"+id, http.StatusOK, "mux")
+
+	// Debug and Logger are looked up when serving requests.
+	var logs bytes.Buffer
+	mux.Logger = log.New(&logs, "", 0)
+	test(v2, "http://www.example.com/v2/panic", http.StatusInternalServerError, "mux")
+	if !strings.Contains(logs.String(), "provoked panic") {
+		t.Fatal("Got:", logs.String(), "Wanted: the panic logged by the logger of the mux")
+	}
+	mux.Debug = true
+	w = test(v2, "http://www.example.com/v2/panic", http.StatusInternalServerError, "mux")
+	if !strings.Contains(w.Body.String(), "provoked panic") {
+		t.Fatal("expected debug info in the response")
+	}
+	v2.SetDebug(false)
+	w = test(v2, "http://www.example.com/v2/panic", http.StatusInternalServerError, "mux")
+	if strings.Contains(w.Body.String(), "provoked panic") {
+		t.Fatal("Debug was not turned off in the group")
+	}
+}
+
+func TestMuxFlags(t *testing.T) {
+	mux := NewMux()
+	group := mux.Group("/v1")
+	nested := group.Group("/admin")
+	if nested.autoETag() {
+		t.Fatal("AutoETag is expected to be off by default")
+	}
+	mux.AutoETag = true
+	if !nested.autoETag() {
+		t.Fatal("AutoETag is expected to be inherited")
+	}
+	group.SetAutoETag(false)
+	if nested.autoETag() || !mux.autoETag() {
+		t.Fatal("AutoETag is expected to be turned off in the group only")
+	}
+	nested.SetAutoETag(true)
+	if !nested.autoETag() || group.autoETag() {
+		t.Fatal("AutoETag is expected to be turned on in the nested group only")
+	}
+	if nested.debug() || nested.conditionsRequired() || nested.paginateRanges() {
+		t.Fatal("flags are expected to be independent")
+	}
+}
+
+func TestMuxURL(t *testing.T) {
+	mux := NewMux()
+	person := mux.HandleEndpoint("/people/{id:\\d+}", &personResource{}).Name("person")
+	if person.GetName() != "person" {
+		t.Fatal("Got:", person.GetName(), "Wanted:", "person")
+	}
+	mux.Group("/admin").HandleEndpoint("/people/{id}", &personResource{}).Name("admin.person")
+
+	var test = func(name string, vars RouteVars, expected string) {
+		u, err := mux.URL(name, vars)
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		if u.String() != expected {
+			t.Fatal(name, "Got:", u.String(), "Wanted:", expected)
+		}
+	}
+	test("person", RouteVars{"id": "42"}, "/people/42")
+	test("admin.person", RouteVars{"id": "root"}, "/admin/people/root")
+
+	if _, err := mux.URL("unknown", nil); err == nil {
+		t.Fatal("expected an error for an unknown route")
+	}
+	if _, err := mux.URL("person", RouteVars{"id": "abc"}); err == nil {
+		t.Fatal("expected an error for vars not matching the pattern")
+	}
+
+	r, _ := http.NewRequest(Get, "http://www.example.com/people", nil)
+	r.Header.Set("X-Forwarded-Proto", "https")
+	u, err := mux.AbsoluteURL(r, "person", RouteVars{"id": "42"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "https://www.example.com/people/42"; u.String() != expected {
+		t.Fatal("Got:", u.String(), "Wanted:", expected)
+	}
+}
+
+func TestMuxRoutes(t *testing.T) {
+	mux := NewMux()
+	mux.HandleEndpoint("/people/{id}", &personResource{}).Name("person")
+	mux.Handle("/raw", http.NotFoundHandler())
+	admin := mux.Group("/admin")
+	admin.Patch("/people/{id}", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return nil, nil
+	})
+	admin.Get("/people/{id}", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return nil, nil
+	})
+
+	routes := mux.Routes()
+	if len(routes) != 3 {
+		t.Fatal("Got:", len(routes), "routes. Wanted: 3")
+	}
+	if routes[0].Pattern() != "/people/{id}" || routes[0].GetName() != "person" {
+		t.Fatal("unexpected route:", routes[0].Pattern(), routes[0].GetName())
+	}
+	if routes[1].Endpoint() != nil || routes[1].Methods() != nil {
+		t.Fatal("handlers are not expected to have an endpoint")
+	}
+	if got := strings.Join(routes[2].Methods(), ","); got != "HEAD,GET,PATCH" {
+		t.Fatal("Got:", got, "Wanted:", "HEAD,GET,PATCH")
+	}
+
+	if routes := admin.Routes(); len(routes) != 1 || routes[0].Pattern() != "/admin/people/{id}" {
+		t.Fatal("a group is expected to only list its own routes")
+	}
+}
+
+func TestVars(t *testing.T) {
+	mux := NewMux()
+	route := mux.Get("/people/{id}", func(vars RouteVars, r *http.Request) (Resource, error) {
+		if vars.Get("id") != "42" || Vars(r).Get("id") != "42" {
+			t.Fatal("Got:", Vars(r), "Wanted: 42")
+		}
+		return nil, nil
+	})
+	mux.Use(func(rt *Route, vars RouteVars, w http.ResponseWriter, r *http.Request, next http.Handler) error {
+		if CurrentRoute(r) != route {
+			t.Fatal("matched route is missing from the context")
+		}
+		// Vars survive a copy of the request.
+		next.ServeHTTP(w, r.WithContext(r.Context()))
+		return nil
+	})
+
+	r, _ := http.NewRequest(Get, "http://www.example.com/people/42", nil)
+	if Vars(r) != nil || CurrentRoute(r) != nil {
+		t.Fatal("a request that was not routed is not expected to have vars")
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	if w.Code != http.StatusNoContent {
+		t.Fatal("Got:", w.Code, "Wanted:", http.StatusNoContent)
+	}
+}
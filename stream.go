@@ -0,0 +1,166 @@
+package rst
+
+import (
+	"io"
+	"net/http"
+	"strings"
+)
+
+/*
+StreamMarshaler is implemented by resources that encode themselves directly in
+the body of the response, without buffering the whole payload in memory.
+
+	func (e *Export) StreamContentType(r *http.Request) (string, error) {
+		return "text/csv; charset=utf-8", nil
+	}
+
+	func (e *Export) MarshalStream(w io.Writer, r *http.Request) error {
+		cw := csv.NewWriter(w)
+		for e.rows.Next() {
+			if err := cw.Write(e.rows.Values()); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+
+The response is sent with chunked transfer encoding, and compressed on the
+fly when the client supports it. The headers describing the resource are sent
+before the first byte. The io.Writer passed to MarshalStream implements
+http.Flusher, to send the data written so far down the transport.
+
+An error returned by MarshalStream is sent to the client if nothing was
+written yet. Otherwise, the response is aborted with http.ErrAbortHandler: the
+connection is closed without terminating the body, so that clients can tell
+it is incomplete.
+*/
+type StreamMarshaler interface {
+	// StreamContentType returns the media type of the representation written
+	// by MarshalStream in response to r, or an error such as NotAcceptable.
+	StreamContentType(*http.Request) (string, error)
+
+	// MarshalStream writes the representation of the resource in w.
+	MarshalStream(io.Writer, *http.Request) error
+}
+
+/*
+Opener is implemented by resources whose representation is read from an
+io.Reader, such as files or exports stored elsewhere. The reader is streamed in
+the response the same way as with StreamMarshaler, and closed once read if it
+implements io.Closer. An error returned while reading, once part of the body
+was sent, aborts the response as well.
+
+	func (f *File) Open(r *http.Request) (string, io.Reader, error) {
+		file, err := os.Open(f.path)
+		return f.contentType, file, err
+	}
+*/
+type Opener interface {
+	Open(*http.Request) (contentType string, reader io.Reader, err error)
+}
+
+// streamWriter writes the status code of a response right before the first
+// byte of its body.
+type streamWriter struct {
+	w     http.ResponseWriter
+	code  int
+	wrote bool
+}
+
+func (sw *streamWriter) Write(b []byte) (int, error) {
+	if len(b) == 0 {
+		return 0, nil
+	}
+	if !sw.wrote {
+		sw.w.WriteHeader(sw.code)
+		sw.wrote = true
+	}
+	return sw.w.Write(b)
+}
+
+// Flush implements http.Flusher.
+func (sw *streamWriter) Flush() {
+	if !sw.wrote {
+		return
+	}
+	if flusher, ok := sw.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// writeStreamer writes the representation of resource if it implements
+// StreamMarshaler or Opener, and returns true. It returns false otherwise.
+func writeStreamer(resource Resource, w http.ResponseWriter, r *http.Request) bool {
+	switch s := resource.(type) {
+	case StreamMarshaler:
+		contentType, err := s.StreamContentType(r)
+		if err != nil {
+			writeError(err, w, r)
+			return true
+		}
+		writeStream(contentType, func(sw io.Writer) error {
+			return s.MarshalStream(sw, r)
+		}, w, r)
+		return true
+
+	case Opener:
+		contentType, reader, err := s.Open(r)
+		if err != nil {
+			writeError(err, w, r)
+			return true
+		}
+		if closer, ok := reader.(io.Closer); ok {
+			defer closer.Close()
+		}
+		writeStream(contentType, func(sw io.Writer) error {
+			_, err := io.Copy(sw, reader)
+			return err
+		}, w, r)
+		return true
+	}
+	return false
+}
+
+// writeStream writes the body produced by stream in contentType, with the
+// appropriate status code and headers.
+func writeStream(contentType string, stream func(io.Writer) error, w http.ResponseWriter, r *http.Request) {
+	streamResponse(w)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Del("Content-Length")
+	if compression := getStreamCompressionFormat(r); compression != "" {
+		w.Header().Set("Content-Encoding", compression)
+		addVary(w.Header(), "Accept-Encoding")
+	}
+
+	sw := &streamWriter{w: w, code: http.StatusOK}
+	switch {
+	case strings.ToUpper(r.Method) == Post:
+		sw.code = http.StatusCreated
+	case w.Header().Get("Content-Range") != "" || strings.HasPrefix(contentType, "multipart/byteranges"):
+		sw.code = http.StatusPartialContent
+	}
+
+	if strings.ToUpper(r.Method) == Head {
+		w.WriteHeader(sw.code)
+		w.Write(noContent)
+		return
+	}
+
+	err := stream(sw)
+	if err != nil && !sw.wrote {
+		w.Header().Del("Content-Encoding")
+		writeError(err, w, r)
+		return
+	}
+	if err != nil {
+		// The status code and part of the body were sent: closing the
+		// connection is the only way left to tell the client.
+		panic(http.ErrAbortHandler)
+	}
+	if !sw.wrote {
+		w.Header().Del("Content-Encoding")
+		w.WriteHeader(sw.code)
+		w.Write(noContent)
+	}
+}
@@ -0,0 +1,203 @@
+package rst
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testExport struct {
+	lines int
+	err   error // Returned halfway, if set.
+}
+
+func (e *testExport) ETag() string            { return "export" }
+func (e *testExport) LastModified() time.Time { return testTimeReference }
+func (e *testExport) TTL() time.Duration      { return 0 }
+
+func (e *testExport) StreamContentType(r *http.Request) (string, error) {
+	if r.Header.Get("Accept") == "application/json" {
+		return "", NotAcceptable()
+	}
+	return "text/plain; charset=utf-8", nil
+}
+
+func (e *testExport) MarshalStream(w io.Writer, r *http.Request) error {
+	for i := 0; i < e.lines; i++ {
+		if _, err := fmt.Fprintf(w, "line %d\n", i); err != nil {
+			return err
+		}
+		if i == e.lines/2 {
+			w.(http.Flusher).Flush()
+			if e.err != nil {
+				return e.err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *testExport) String() string {
+	var buf bytes.Buffer
+	for i := 0; i < e.lines; i++ {
+		fmt.Fprintf(&buf, "line %d\n", i)
+	}
+	return buf.String()
+}
+
+type testFile struct {
+	*strings.Reader
+	closed bool
+}
+
+func (f *testFile) Close() error {
+	f.closed = true
+	return nil
+}
+
+type testFileResource struct {
+	file *testFile
+}
+
+func (f *testFileResource) ETag() string            { return "file" }
+func (f *testFileResource) LastModified() time.Time { return testTimeReference }
+func (f *testFileResource) TTL() time.Duration      { return 0 }
+
+func (f *testFileResource) Open(r *http.Request) (string, io.Reader, error) {
+	return "text/plain", f.file, nil
+}
+
+func TestStreamMarshaler(t *testing.T) {
+	export := &testExport{lines: 50000}
+	mux := NewMux()
+	mux.Get("/export", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return export, nil
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+	var test = func(method, accept, encoding string) *http.Response {
+		r, _ := http.NewRequest(method, server.URL+"/export", nil)
+		r.Header.Set("Accept", accept)
+		r.Header.Set("Accept-Encoding", encoding)
+		resp, err := client.Do(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	for _, encoding := range []string{"", "gzip", "deflate"} {
+		resp := test(Get, "text/plain", encoding)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatal(encoding, "Got:", resp.StatusCode, "Wanted:", http.StatusOK)
+		}
+		if len(resp.TransferEncoding) == 0 || resp.TransferEncoding[0] != "chunked" {
+			t.Fatal(encoding, "response was not chunked:", resp.TransferEncoding)
+		}
+		if resp.Header.Get("ETag") != `"export"` || resp.Header.Get("Last-Modified") == "" || !strings.Contains(resp.Header.Get("Vary"), "Accept") {
+			t.Fatal(encoding, "missing headers:", resp.Header)
+		}
+		if got := resp.Header.Get("Content-Encoding"); got != encoding {
+			t.Fatal("Got:", got, "Wanted:", encoding)
+		}
+
+		var body []byte
+		if encoding == "" {
+			body, _ = ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+		} else {
+			body, _ = decompress(resp.Body, encoding)
+		}
+		if string(body) != export.String() {
+			t.Fatal(encoding, "Got:", len(body), "bytes. Wanted:", len(export.String()))
+		}
+	}
+
+	if resp := test(Get, "application/json", "gzip"); resp.StatusCode != http.StatusNotAcceptable || resp.Header.Get("Content-Encoding") != "" {
+		t.Fatal("Got:", resp.StatusCode, "Wanted:", http.StatusNotAcceptable)
+	}
+
+	resp := test(Head, "text/plain", "")
+	body, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || len(body) != 0 {
+		t.Fatal("unexpected HEAD response:", resp.StatusCode, len(body))
+	}
+}
+
+func TestStreamAbort(t *testing.T) {
+	mux := NewMux()
+	mux.Get("/export", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return &testExport{lines: 1000, err: errors.New("export failed")}, nil
+	})
+	mux.Get("/items", func(vars RouteVars, r *http.Request) (Resource, error) {
+		sent := false
+		return NewCollection(IteratorFunc(func() (interface{}, error) {
+			if sent {
+				return nil, errors.New("iteration failed")
+			}
+			sent = true
+			return &collectionItem{1}, nil
+		}), testTimeReference, "", 0), nil
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+	for _, path := range []string{"/export", "/items"} {
+		for _, encoding := range []string{"", "gzip"} {
+			r, _ := http.NewRequest(Get, server.URL+path, nil)
+			r.Header.Set("Accept", "text/plain, application/json")
+			r.Header.Set("Accept-Encoding", encoding)
+			resp, err := client.Do(r)
+			if err != nil {
+				// Nothing was sent before the response was aborted.
+				continue
+			}
+			if resp.StatusCode != http.StatusOK {
+				t.Fatal(path, encoding, "Got:", resp.StatusCode, "Wanted:", http.StatusOK)
+			}
+			var reader io.Reader = resp.Body
+			if encoding == "gzip" {
+				if reader, err = gzip.NewReader(resp.Body); err != nil {
+					continue
+				}
+			}
+			_, err = ioutil.ReadAll(reader)
+			resp.Body.Close()
+			if err == nil {
+				t.Fatal(path, encoding, "an aborted response was read as complete")
+			}
+		}
+	}
+}
+
+func TestOpener(t *testing.T) {
+	resource := &testFileResource{file: &testFile{Reader: strings.NewReader(testCannedContent)}}
+	mux := NewMux()
+	mux.Get("/file", func(vars RouteVars, r *http.Request) (Resource, error) {
+		return resource, nil
+	})
+
+	r, _ := http.NewRequest(Get, "http://www.example.com/file", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	if w.Code != http.StatusOK || w.Body.String() != testCannedContent {
+		t.Fatal("Got:", w.Code, w.Body.String(), "Wanted:", http.StatusOK, testCannedContent)
+	}
+	if w.Header().Get("Content-Type") != "text/plain" || w.Header().Get("ETag") != `"file"` {
+		t.Fatal("unexpected headers:", w.Header())
+	}
+	if !resource.file.closed {
+		t.Fatal("reader was not closed")
+	}
+}
@@ -0,0 +1,93 @@
+package rst
+
+import (
+	"strings"
+)
+
+/*
+Validatable is implemented by types wishing to be validated after having been
+decoded by Unmarshal.
+
+	type Person struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+
+	func (p *Person) Validate(v *rst.Validator) {
+		v.Check(p.Name != "", "/name", "required", "Name is required.")
+		v.Check(p.Age >= 18, "/age", "min", "Must be at least 18 years old.")
+	}
+*/
+type Validatable interface {
+	// Validate must report the violations found in the value to v.
+	Validate(v *Validator)
+}
+
+/*
+Validator accumulates the violations found while validating the entity of a
+request. The zero value is ready to use.
+
+	v := new(rst.Validator)
+	v.Check(person.Name != "", "/name", "required", "Name is required.")
+	for i, email := range person.Emails {
+		v.Check(strings.Contains(email, "@"), rst.JSONPointer("emails", strconv.Itoa(i)), "format", "Invalid email address.")
+	}
+	if err := v.Err(); err != nil {
+		return nil, "", err // 422 Unprocessable Entity
+	}
+*/
+type Validator struct {
+	violations []*Violation
+}
+
+// Add reports a violation for the field found at path, which is a JSON
+// pointer.
+func (v *Validator) Add(path, code, message string) {
+	v.violations = append(v.violations, &Violation{
+		Path:    path,
+		Code:    code,
+		Message: message,
+	})
+}
+
+// Check reports a violation for the field found at path if ok is false, and
+// returns ok.
+func (v *Validator) Check(ok bool, path, code, message string) bool {
+	if !ok {
+		v.Add(path, code, message)
+	}
+	return ok
+}
+
+// Valid returns true if no violation was reported.
+func (v *Validator) Valid() bool {
+	return len(v.violations) == 0
+}
+
+// Violations returns the violations reported so far.
+func (v *Validator) Violations() []*Violation {
+	return v.violations
+}
+
+// Err returns an UnprocessableEntity error listing the violations reported, or
+// nil if there are none.
+func (v *Validator) Err() error {
+	if v.Valid() {
+		return nil
+	}
+	return UnprocessableEntity(v.violations...)
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// JSONPointer returns the JSON pointer (RFC 6901) referencing the value found by
+// following tokens from the root of a document.
+//
+//	JSONPointer("addresses", "0", "zip-code") // "/addresses/0/zip-code"
+func JSONPointer(tokens ...string) string {
+	var pointer string
+	for _, token := range tokens {
+		pointer += "/" + pointerEscaper.Replace(token)
+	}
+	return pointer
+}
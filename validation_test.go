@@ -0,0 +1,110 @@
+package rst
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type validatedPerson struct {
+	Firstname string   `json:"firstname"`
+	Age       int      `json:"age"`
+	Emails    []string `json:"emails"`
+}
+
+func (p *validatedPerson) Validate(v *Validator) {
+	v.Check(p.Firstname != "", "/firstname", "required", "Firstname is required.")
+	v.Check(p.Age >= 18, "/age", "min", "Must be at least 18 years old.")
+	for i, email := range p.Emails {
+		v.Check(strings.Contains(email, "@"), JSONPointer("emails", strconv.Itoa(i)), "format", "Invalid email address.")
+	}
+}
+
+func TestJSONPointer(t *testing.T) {
+	var test = func(expected string, tokens ...string) {
+		if got := JSONPointer(tokens...); got != expected {
+			t.Error("Got:", got, "Wanted:", expected)
+		}
+	}
+	test("")
+	test("/addresses/0/zip", "addresses", "0", "zip")
+	test("/a~1b/m~0n", "a/b", "m~n")
+}
+
+func TestValidator(t *testing.T) {
+	v := new(Validator)
+	if !v.Valid() || v.Err() != nil {
+		t.Fatal("empty validator should be valid")
+	}
+
+	if v.Check(false, "/name", "required", "Name is required.") {
+		t.Fatal("Check should return false")
+	}
+	v.Add("/age", "min", "Too young.")
+
+	e, valid := v.Err().(*Error)
+	if !valid || e.Code != http.StatusUnprocessableEntity {
+		t.Fatal("Expecting error with code 422. Got:", v.Err())
+	}
+	if len(e.Violations) != 2 || e.Violations[1].Path != "/age" {
+		t.Fatal("unexpected violations:", e.Violations)
+	}
+}
+
+func TestUnmarshalValidatable(t *testing.T) {
+	var p validatedPerson
+	r := newEntityRequest("application/json", `{"firstname": "Francis", "age": 55, "emails": ["francis@example.com"]}`)
+	if err := Unmarshal(r, &p); err != nil {
+		t.Fatal(err)
+	}
+
+	r = newEntityRequest("application/json", `{"age": 12, "emails": ["francis@example.com", "francis"]}`)
+	e, valid := Unmarshal(r, new(validatedPerson)).(*Error)
+	if !valid || e.Code != http.StatusUnprocessableEntity {
+		t.Fatal("Expecting error with code 422. Got:", e)
+	}
+	expected := []string{"/firstname", "/age", "/emails/1"}
+	if len(e.Violations) != len(expected) {
+		t.Fatal("Got:", e.Violations, "Wanted:", expected)
+	}
+	for i, v := range e.Violations {
+		if v.Path != expected[i] {
+			t.Error("Got:", v.Path, "Wanted:", expected[i])
+		}
+	}
+}
+
+func TestUnprocessableEntityRendering(t *testing.T) {
+	e := UnprocessableEntity(&Violation{Path: "/age", Code: "min", Message: "Must be at least 18 years old."})
+
+	var test = func(accept string) []byte {
+		r, _ := http.NewRequest(Post, "http://www.example.com/people", nil)
+		r.Header.Set("Accept", accept)
+		_, b, err := e.MarshalRST(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	var decoded struct {
+		Violations []*Violation `json:"violations"`
+	}
+	if err := json.Unmarshal(test("application/json"), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Violations) != 1 || *decoded.Violations[0] != *e.Violations[0] {
+		t.Fatal("unexpected JSON rendering:", decoded.Violations)
+	}
+
+	if b := test("application/xml"); !bytes.Contains(b, []byte("<Violations><Violation><Path>/age</Path>")) {
+		t.Fatal("unexpected XML rendering:", string(b))
+	}
+
+	if b := test("text/html"); !bytes.Contains(b, []byte("<code>/age</code>")) {
+		t.Fatal("violations missing from the HTML rendering")
+	}
+}